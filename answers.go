@@ -0,0 +1,319 @@
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// questionVisible reports whether q should be asked given the answers collected so far
+func questionVisible(q *Question, answers map[string]interface{}) bool {
+	if q.When == "" {
+		return true
+	}
+
+	visible, err := EvalCondition(q.When, answers)
+	if err != nil {
+		log.Warnf("COULD NOT EVALUATE WHEN EXPRESSION OF %s: %v", q.Name, err)
+		return false
+	}
+	return visible
+}
+
+// collectAnswers walks the questions in order and returns the answers of all visible ones
+func collectAnswers(questions []*Question) map[string]interface{} {
+	return collectInto(questions, make(map[string]interface{}))
+}
+
+// scopedAnswers collects the answers of questions on top of a copy of the outer answers (if any)
+func scopedAnswers(outer func() map[string]interface{}, questions []*Question) map[string]interface{} {
+	answers := make(map[string]interface{})
+	if outer != nil {
+		for name, value := range outer() {
+			answers[name] = value
+		}
+	}
+	return collectInto(questions, answers)
+}
+
+// collectInto adds the answers of all visible questions to answers, which also holds the answers visible to them
+func collectInto(questions []*Question, answers map[string]interface{}) map[string]interface{} {
+	for _, question := range questions {
+		if !questionVisible(question, answers) {
+			continue
+		}
+
+		if question.Kind == "computed" {
+			value, err := computedValue(question, answers)
+			if err != nil {
+				log.Warnf("COULD NOT COMPUTE %s: %v", question.Name, err)
+				continue
+			}
+			answers[question.Name] = value
+			continue
+		}
+
+		if question.Kind == "repeat" {
+			items, _ := repeatItems(question, answers, func(children []*Question, scope map[string]interface{}) error {
+				collectInto(children, scope)
+				return nil
+			})
+			answers[question.Name] = items
+			continue
+		}
+
+		answers[question.Name] = answerValue(question)
+
+		if len(question.Foreach) > 0 {
+			items, _ := eachItems(question, answers, func(children []*Question, scope map[string]interface{}) error {
+				collectInto(children, scope)
+				return nil
+			})
+			answers[eachName(question)] = items
+		}
+	}
+
+	return answers
+}
+
+// computedValue evaluates a computed question against the answers given so far: its expr as expression
+// or else its default as template. The result is converted to the type of the question if one is given.
+func computedValue(q *Question, answers map[string]interface{}) (interface{}, error) {
+	if q.answered {
+		return answerValue(q), nil
+	}
+
+	var value interface{}
+	if q.Expr != "" {
+		result, err := EvalExpression(q.Expr, answers)
+		if err != nil {
+			return nil, err
+		}
+		value = result
+	} else {
+		rendered, err := renderTemplate(q.Default, answers)
+		if err != nil {
+			return nil, err
+		}
+		value = rendered
+	}
+
+	if q.Type != "" {
+		return ConvertToType(toString(value), q.Type), nil
+	}
+	return value, nil
+}
+
+// answerValue returns the answer of q converted to its type, confirm questions yield a bool,
+// list questions a slice and secret questions a Secret
+func answerValue(q *Question) interface{} {
+	switch q.Kind {
+	case "confirm":
+		return truthy(q.Default)
+	case "list":
+		return listValue(q)
+	}
+	if isSecret(q) {
+		return Secret(q.Default)
+	}
+	return ConvertToType(q.Default, q.Type)
+}
+
+// listValue returns the selected options of a list question as a slice of its type, e.g. []int for "int" or "[]int"
+func listValue(q *Question) interface{} {
+	return ConvertToType(q.Default, "[]"+strings.TrimPrefix(q.Type, "[]"))
+}
+
+// listItems splits the comma separated selection of a list question, an empty selection yields an empty slice
+func listItems(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// isInput reports whether q is answered by typing, these answers are checked by validateInput
+func isInput(q *Question) bool {
+	switch q.Kind {
+	case "ask", "function", "password", "text":
+		return true
+	}
+	return false
+}
+
+// LoadAnswersFile reads a YAML or JSON file with answers by question name
+func LoadAnswersFile(filename string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %s: %w", filename, err)
+	}
+
+	return answers, nil
+}
+
+// ApplyAnswers presets the questions found in answers, these are not prompted anymore.
+// Dotted names like vm.network.vlan are found as flat keys or in nested maps.
+// Follow-up answers of list options are read from <name>_each by option value.
+func ApplyAnswers(questions []*Question, answers map[string]interface{}) {
+	for _, question := range questions {
+		if value, ok := lookupAnswer(answers, question.Name); ok {
+			presetAnswer(question, value)
+		}
+		if len(question.Foreach) == 0 {
+			continue
+		}
+		if value, ok := lookupAnswer(answers, eachName(question)); ok {
+			presetEach(question, value)
+		}
+	}
+}
+
+// presetAnswer stores value as the answer of q, matching it against the options if there are any
+func presetAnswer(q *Question, value interface{}) {
+	if q.Kind == "repeat" {
+		presetRepeat(q, value)
+		return
+	}
+
+	answer := toString(value)
+
+	// LIST ANSWERS ARE STORED COMMA SEPARATED
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice {
+		items := toSlice(value)
+		values := make([]string, len(items))
+		for i, item := range items {
+			values[i] = toString(item)
+		}
+		answer = strings.Join(values, ",")
+	}
+
+	// USE THE SPELLING OF THE MATCHING OPTION, E.G. "Yes" FOR true
+	for _, option := range optionValues(q.Options) {
+		if looseEqual(option, value) {
+			answer = option
+			break
+		}
+	}
+
+	q.Default = answer
+	q.answered = true
+}
+
+// nestAnswers returns a copy of answers with dotted names like vm.network.vlan turned into nested maps,
+// also inside the entries of repeat blocks. A dotted name stays flat if one of its parents is an answer itself.
+func nestAnswers(answers map[string]interface{}) map[string]interface{} {
+	nested := make(map[string]interface{}, len(answers))
+	values := make(map[string]interface{}, len(answers))
+	var dotted []string
+
+	for name, value := range answers {
+		if items, ok := value.([]map[string]interface{}); ok {
+			nestedItems := make([]map[string]interface{}, len(items))
+			for i, item := range items {
+				nestedItems[i] = nestAnswers(item)
+			}
+			value = nestedItems
+		}
+
+		if strings.Contains(name, ".") {
+			dotted = append(dotted, name)
+			values[name] = value
+			continue
+		}
+		nested[name] = value
+	}
+
+	sort.Strings(dotted)
+	for _, name := range dotted {
+		parts := strings.Split(name, ".")
+		parent := nested
+
+		for _, part := range parts[:len(parts)-1] {
+			child, exists := parent[part]
+			if !exists {
+				child = make(map[string]interface{})
+				parent[part] = child
+			}
+			m, ok := child.(map[string]interface{})
+			if !ok {
+				parent = nil
+				break
+			}
+			parent = m
+		}
+
+		if parent == nil {
+			nested[name] = values[name]
+			continue
+		}
+		parent[parts[len(parts)-1]] = values[name]
+	}
+
+	return nested
+}
+
+// ApplyEnvOverrides presets questions from environment variables, the variable of a question is its
+// env field or prefix + the upper cased name (e.g. SURVEY_USERNAME), values are converted by its type.
+// Values which cannot be converted are preset as given and reported when the answers are validated.
+func ApplyEnvOverrides(questions []*Question, prefix string) {
+	for _, question := range questions {
+		for _, name := range envNames(question, prefix) {
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				continue
+			}
+			if converted, err := parseValue(value, question.Type); err == nil {
+				presetAnswer(question, converted)
+			} else {
+				presetAnswer(question, value)
+			}
+			break
+		}
+	}
+}
+
+// envNames returns the environment variables that may override q, in order of precedence
+func envNames(q *Question, prefix string) []string {
+	var names []string
+	if q.Env != "" {
+		names = append(names, q.Env)
+	}
+
+	if prefix != "" {
+		name := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToUpper(r)
+			}
+			return '_'
+		}, q.Name)
+		names = append(names, prefix+name)
+	}
+
+	return names
+}
+
+// ExportAnswers marshals answers as "yaml" or "json", secret answers are redacted
+func ExportAnswers(answers map[string]interface{}, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return json.MarshalIndent(answers, "", "  ")
+	case "yaml", "yml", "":
+		return yaml.Marshal(answers)
+	}
+	return nil, fmt.Errorf("UNKNOWN EXPORT FORMAT %s", format)
+}
@@ -0,0 +1,204 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAnswersFile(t *testing.T) {
+	for name, content := range map[string]string{
+		"yaml": "username: patrick\nage: 42\nlikes_coffee: true\n",
+		"json": `{"username": "patrick", "age": 42, "likes_coffee": true}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			filename := createTempYAMLFile(t, content)
+			defer func() {
+				err := os.Remove(filename)
+				assert.NoError(t, err)
+			}()
+
+			answers, err := LoadAnswersFile(filename)
+			assert.NoError(t, err)
+			assert.Equal(t, "patrick", answers["username"])
+			assert.Equal(t, 42, answers["age"])
+			assert.Equal(t, true, answers["likes_coffee"])
+		})
+	}
+
+	_, err := LoadAnswersFile("nonexistent.yaml")
+	assert.Error(t, err)
+}
+
+func TestApplyAnswers(t *testing.T) {
+	questions := []*Question{
+		{Name: "username", Kind: "ask"},
+		{Name: "age", Kind: "ask", Type: "int", Default: "25"},
+		{Name: "likes_coffee", Kind: "select", Options: NewOptions("Yes", "No"), Type: "boolean"},
+		{Name: "color", Kind: "select", Options: NewOptions("Red", "Blue"), Default: "Blue"},
+	}
+
+	ApplyAnswers(questions, map[string]interface{}{
+		"username":     "patrick",
+		"age":          42,
+		"likes_coffee": true,
+	})
+
+	assert.Equal(t, "patrick", questions[0].Default)
+	assert.Equal(t, "42", questions[1].Default)
+	assert.Equal(t, "Yes", questions[2].Default)
+	assert.True(t, questions[2].answered)
+	assert.False(t, questions[3].answered)
+}
+
+func TestRunnerWithAnswersFile(t *testing.T) {
+	filename := createTempYAMLFile(t, "username: patrick\ncolor: Red\n")
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions := []*Question{
+		{Name: "username", Kind: "ask", MinLength: 2},
+		{Name: "color", Kind: "select", Options: NewOptions("Red", "Blue"), Default: "Blue"},
+		{Name: "shade", Kind: "ask", Default: "dark", When: "color == 'Red'"},
+	}
+
+	answers, err := NewRunner(
+		WithQuestions(questions),
+		WithAnswersFile(filename),
+		WithRandomSelects(true),
+		WithInteractive(false),
+	).Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"username": "patrick", "color": "Red", "shade": "dark"}, answers)
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Setenv("SURVEY_USERNAME", "patrick")
+	t.Setenv("SURVEY_LIKES_COFFEE", "true")
+	t.Setenv("VM_SIZE", "large")
+	t.Setenv("SURVEY_AGE", "42")
+
+	questions := []*Question{
+		{Name: "username", Kind: "ask"},
+		{Name: "likes_coffee", Kind: "select", Options: NewOptions("Yes", "No"), Type: "boolean"},
+		{Name: "size", Kind: "ask", Env: "VM_SIZE"},
+		{Name: "color", Kind: "ask", Default: "Blue"},
+	}
+
+	ApplyEnvOverrides(questions, "SURVEY_")
+
+	assert.Equal(t, "patrick", questions[0].Default)
+	assert.Equal(t, "Yes", questions[1].Default)
+	assert.Equal(t, "large", questions[2].Default)
+	assert.Equal(t, "Blue", questions[3].Default)
+	assert.False(t, questions[3].answered)
+
+	answers, err := NewRunner(
+		WithQuestions([]*Question{{Name: "age", Kind: "ask", Type: "int", Default: "25"}}),
+		WithEnvPrefix("SURVEY_"),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, answers["age"])
+
+	// INVALID VALUES ARE REPORTED INSTEAD OF BECOMING ZERO VALUES
+	t.Setenv("SURVEY_AGE", "old")
+	t.Setenv("SURVEY_PORTS", "80,http")
+	_, err = NewRunner(
+		WithQuestions([]*Question{{Name: "age", Kind: "ask", Type: "int", Default: "25"}}),
+		WithEnvPrefix("SURVEY_"),
+		WithInteractive(false),
+	).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "age", validationErr.Question)
+
+	_, err = NewRunner(
+		WithQuestions([]*Question{{Name: "ports", Kind: "list", Type: "int"}}),
+		WithEnvPrefix("SURVEY_"),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "ports", validationErr.Question)
+
+	// BOOLEANS ARE READ LIKE CONDITIONS DO, OTHER VALUES ARE REPORTED
+	for _, kind := range []string{"ask", "confirm"} {
+		for value, want := range map[string]bool{"1": true, "yes": true, "Off": false} {
+			t.Setenv("SURVEY_HA", value)
+			answers, err := NewRunner(
+				WithQuestions([]*Question{{Name: "ha", Kind: kind, Type: "boolean"}}),
+				WithEnvPrefix("SURVEY_"),
+				WithInteractive(false),
+			).Run(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, want, answers["ha"], "%s %s", kind, value)
+		}
+
+		t.Setenv("SURVEY_HA", "maybe")
+		_, err = NewRunner(
+			WithQuestions([]*Question{{Name: "ha", Kind: kind, Type: "boolean"}}),
+			WithEnvPrefix("SURVEY_"),
+			WithInteractive(false),
+		).Run(context.Background())
+		assert.True(t, errors.As(err, &validationErr), kind)
+	}
+}
+
+func TestNestAnswers(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"hostname": "web01",
+		"vm": map[string]interface{}{
+			"cpus":    4,
+			"network": map[string]interface{}{"vlan": 42, "dhcp": true},
+		},
+		"disks": []map[string]interface{}{
+			{"size": 20, "mount": map[string]interface{}{"path": "/data"}},
+		},
+		"tags":      "a",
+		"tags.team": "platform",
+	}, nestAnswers(map[string]interface{}{
+		"hostname":        "web01",
+		"vm.cpus":         4,
+		"vm.network.vlan": 42,
+		"vm.network.dhcp": true,
+		"disks":           []map[string]interface{}{{"size": 20, "mount.path": "/data"}},
+		"tags":            "a",
+		"tags.team":       "platform",
+	}))
+}
+
+func TestRunnerNestedAnswers(t *testing.T) {
+	questions := func() []*Question {
+		return []*Question{
+			{Name: "vm.name", Kind: "ask", Default: "web01"},
+			{Name: "vm.network.vlan", Kind: "ask", Type: "int", Default: "10"},
+			{Name: "vm.network.gateway", Kind: "computed", Expr: `"10.0." + vm.network.vlan + ".1"`},
+		}
+	}
+
+	// NESTED ANSWERS FILES PRESET DOTTED NAMES
+	filename := createTempYAMLFile(t, "vm:\n  network:\n    vlan: 42\n")
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	answers, err := NewRunner(WithQuestions(questions()), WithAnswersFile(filename), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{
+		"vm": map[string]interface{}{
+			"name":    "web01",
+			"network": map[string]interface{}{"vlan": 42, "gateway": "10.0.42.1"},
+		},
+	}, answers)
+
+	answers, err = NewRunner(WithQuestions(questions()), WithFlatAnswers(true), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"vm.name": "web01", "vm.network.vlan": 10, "vm.network.gateway": "10.0.10.1"}, answers)
+}
@@ -3,6 +3,7 @@ package survey
 import (
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,140 +19,404 @@ func RegisterFunction(name string, fn func(params map[string]interface{}) string
 	DefaultFunctions[name] = fn
 }
 
+// defaultBoolAccessor binds a confirm field to the string default of a question
+type defaultBoolAccessor struct {
+	question *Question
+}
+
+func (a *defaultBoolAccessor) Get() bool {
+	return truthy(a.question.Default)
+}
+
+func (a *defaultBoolAccessor) Set(value bool) {
+	a.question.Default = strconv.FormatBool(value)
+}
+
+// defaultListAccessor binds a multi select field to the comma separated default of a question
+type defaultListAccessor struct {
+	question *Question
+}
+
+func (a *defaultListAccessor) Get() []string {
+	return listItems(a.question.Default)
+}
+
+func (a *defaultListAccessor) Set(value []string) {
+	a.question.Default = strings.Join(value, ",")
+}
+
+// validateSelection checks the number of selected options against the limits of the question
+func validateSelection(question *Question, selected []string) error {
+	if len(selected) < question.MinItems {
+		return fmt.Errorf("TOO FEW OPTIONS SELECTED, MINIMUM IS %d", question.MinItems)
+	}
+	if question.MaxItems > 0 && len(selected) > question.MaxItems {
+		return fmt.Errorf("TOO MANY OPTIONS SELECTED, MAXIMUM IS %d", question.MaxItems)
+	}
+	return nil
+}
+
+// validateInput checks a typed answer against the length limits of the question, a MaxLength of 0 means unlimited.
+// Answers of numeric types must be valid numbers (or durations) within the min and max bounds instead,
+// answers of every type must be valid values of the type. Finally the answer has to pass the validate rules of the question.
+func validateInput(question *Question, input string) error {
+	value, err := parseValue(input, question.Type)
+	if err != nil {
+		return err
+	}
+
+	if isNumericType(question.Type) {
+		if err := validateRange(question, value); err != nil {
+			return err
+		}
+		return runValidators(question, input)
+	}
+
+	if len(input) < question.MinLength {
+		return fmt.Errorf("INPUT TOO SHORT, MINIMUM LENGTH IS %d", question.MinLength)
+	}
+	if question.MaxLength > 0 && len(input) > question.MaxLength {
+		return fmt.Errorf("INPUT TOO LONG, MAXIMUM LENGTH IS %d", question.MaxLength)
+	}
+	return runValidators(question, input)
+}
+
 // BUILD THE SURVEY FUNCTION WITH THE NEW RANDOM SETUP
-func BuildSurvey(questions []*Question) (*huh.Form, map[string]interface{}, error) {
+// QUESTIONS WITH A PAGE ARE SHOWN TOGETHER, PAGES ARE ORDERED AND DESCRIBED BY pages
+func BuildSurvey(questions []*Question, pages ...Page) (*huh.Form, map[string]interface{}, error) {
+	return BuildSurveyForm(&Survey{Questions: questions, Pages: pages})
+}
+
+// BuildSurveyForm builds the form of a survey, the intro and outro of the survey are shown
+// as notes before the first and after the last question
+func BuildSurveyForm(survey *Survey) (*huh.Form, map[string]interface{}, error) {
+	groupFields, answers, err := buildGroups(orderPages(survey.Questions, survey.Pages), nil, nil, survey.Pages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if survey.Intro != "" {
+		intro := huh.NewNote().
+			Title(survey.heading()).
+			Description(strings.TrimSpace(survey.Description + "\n\n" + survey.Intro)).
+			Next(true).
+			NextLabel("Start")
+		groupFields = append([]*huh.Group{huh.NewGroup(intro)}, groupFields...)
+	}
+	if survey.Outro != "" {
+		outro := huh.NewNote().
+			Title(survey.Title).
+			Description(survey.Outro).
+			Next(true).
+			NextLabel("Finish")
+		groupFields = append(groupFields, huh.NewGroup(outro))
+	}
+
+	return huh.NewForm(groupFields...), answers, nil
+}
+
+// heading returns the title of the survey followed by its version, e.g. "VM request v1.2.0"
+func (s *Survey) heading() string {
+	return strings.TrimSpace(s.Title + " " + s.Version)
+}
+
+// buildGroups builds a group per question or page. outer returns the answers given outside of questions (e.g. before
+// a repeat block) and hidden hides all groups (e.g. of repeat entries beyond the entered count), both may be nil.
+func buildGroups(questions []*Question, outer func() map[string]interface{}, hidden func() bool, pages []Page) ([]*huh.Group, map[string]interface{}, error) {
 	var groupFields []*huh.Group
+	pageGroups := make(map[string]*pageGroup)
+	var pageNames []string
 	answers := make(map[string]interface{})
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	for _, question := range questions {
+	for i, question := range questions {
 		var field huh.Field
+		var extraFields []huh.Field
+		rebind := func() {}
+		previous := questions[:i]
+		previousAnswers := func() map[string]interface{} {
+			return scopedAnswers(outer, previous)
+		}
+
+		// CHECK THE WHEN EXPRESSION BEFORE IT IS EVALUATED INSIDE THE FORM
+		if question.When != "" {
+			if _, err := parseExpression(question.When); err != nil {
+				return nil, nil, fmt.Errorf("INVALID WHEN EXPRESSION FOR %s: %w", question.Name, err)
+			}
+		}
+
+		// COMPUTED QUESTIONS ARE NEVER PROMPTED, THEY ARE EVALUATED WHEN THE ANSWERS ARE COLLECTED
+		if question.Kind == "computed" {
+			if question.Expr != "" {
+				if _, err := parseExpression(question.Expr); err != nil {
+					return nil, nil, fmt.Errorf("INVALID EXPRESSION FOR %s: %w", question.Name, err)
+				}
+			}
+			continue
+		}
+
+		// CHECK THE VALIDATORS BEFORE THEY ARE RUN INSIDE THE FORM
+		if err := checkValidators(question); err != nil {
+			return nil, nil, fmt.Errorf("INVALID VALIDATE RULE FOR %s: %w", question.Name, err)
+		}
+
+		// REPEAT BLOCKS ASK FOR THE NUMBER OF ENTRIES, FOLLOWED BY THE CHILD QUESTIONS OF EACH ENTRY
+		if question.Kind == "repeat" {
+			repeatGroups, err := buildRepeat(question, previousAnswers, hidden)
+			if err != nil {
+				return nil, nil, fmt.Errorf("INVALID REPEAT BLOCK %s: %w", question.Name, err)
+			}
+			groupFields = append(groupFields, repeatGroups...)
+			continue
+		}
+
+		// RENDER TEMPLATED DEFAULTS AGAINST THE DEFAULTS OF THE PREVIOUS QUESTIONS
+		templated := !question.answered && hasTemplate(question)
+		if templated {
+			defaultValue, err := resolveDefault(question, previousAnswers())
+			if err != nil {
+				return nil, nil, fmt.Errorf("INVALID DEFAULT TEMPLATE FOR %s: %w", question.Name, err)
+			}
+			question.Default = defaultValue
+			question.renderedDefault = defaultValue
+		}
+
+		// LOAD DYNAMIC OPTIONS FROM THE REGISTERED OPTIONS FUNCTION
+		if err := resolveOptions(question, previousAnswers()); err != nil {
+			return nil, nil, err
+		}
 
 		// Set up default values for options if applicable
-		if question.Default == "" && len(question.Options) > 0 {
-			question.Default = question.Options[r.Intn(len(question.Options))]
+		if question.Default == "" && len(question.Options) > 0 && !question.answered {
+			question.Default = question.Options[r.Intn(len(question.Options))].Value
 		}
 
 		switch question.Kind {
 		case "function":
-			if question.DefaultFunction != "" {
-				if fn, ok := DefaultFunctions[question.DefaultFunction]; ok {
-					question.Default = fn(question.DefaultParams)
-				} else {
-					return nil, nil, fmt.Errorf("DEFAULT FUNCTION %s NOT FOUND", question.DefaultFunction)
+			if question.DefaultFunction != "" && !templated && !question.answered {
+				defaultValue, err := resolveDefault(question, nil)
+				if err != nil {
+					return nil, nil, err
 				}
+				question.Default = defaultValue
 			}
 
-			field = huh.NewInput().
+			input := huh.NewInput()
+			rebind = func() { input.Value(&question.Default) }
+			field = input.
 				Title(question.Prompt).
 				Value(&question.Default).
 				Validate(func(input string) error {
-					if len(input) < question.MinLength {
-						return fmt.Errorf("INPUT TOO SHORT, MINIMUM LENGTH IS %d", question.MinLength)
-					}
-					if len(input) > question.MaxLength {
-						return fmt.Errorf("INPUT TOO LONG, MAXIMUM LENGTH IS %d", question.MaxLength)
-					}
-					return nil
+					return validateInput(question, input)
 				})
 
-		case "ask":
-			field = huh.NewInput().
+		case "ask", "password":
+			input := huh.NewInput()
+			rebind = func() { input.Value(&question.Default) }
+			field = input.
 				Title(question.Prompt).
 				Value(&question.Default).
 				Validate(func(input string) error {
-					if len(input) < question.MinLength {
-						return fmt.Errorf("INPUT TOO SHORT, MINIMUM LENGTH IS %d", question.MinLength)
-					}
-					if len(input) > question.MaxLength {
-						return fmt.Errorf("INPUT TOO LONG, MAXIMUM LENGTH IS %d", question.MaxLength)
-					}
-					return nil
+					return validateInput(question, input)
 				})
 
-			answers[question.Name] = ""
+			if isSecret(question) {
+				input.EchoMode(huh.EchoModePassword)
+			}
 
-		case "list":
-			var defaultValues []string
-			if question.Default != "" {
-				defaultValues = strings.Split(question.Default, ",")
+			// LET THE USER REPEAT THE INPUT TO CATCH TYPOS
+			if question.Verify {
+				var repeated string
+				verifyInput := huh.NewInput().
+					Title("Repeat: " + question.Prompt).
+					Value(&repeated).
+					Validate(func(input string) error {
+						if input != question.Default {
+							return fmt.Errorf("INPUTS DO NOT MATCH")
+						}
+						return nil
+					})
+				if isSecret(question) {
+					verifyInput.EchoMode(huh.EchoModePassword)
+				}
+				extraFields = append(extraFields, verifyInput)
 			}
 
-			options := make([]huh.Option[string], len(question.Options))
-			for i, opt := range question.Options {
-				options[i] = huh.NewOption(opt, opt)
+			answers[question.Name] = ""
+
+		case "text":
+			text := huh.NewText()
+			rebind = func() { text.Value(&question.Default) }
+			field = text.
+				Title(question.Prompt).
+				Value(&question.Default).
+				Validate(func(input string) error {
+					return validateInput(question, input)
+				})
+
+			// A CHAR LIMIT OF 0 REJECTS EVERY INPUT IN ACCESSIBLE MODE
+			if question.MaxLength > 0 {
+				text.CharLimit(question.MaxLength)
+			}
+			if question.Lines > 0 {
+				text.Lines(question.Lines)
 			}
 
-			field = huh.NewMultiSelect[string]().
+			answers[question.Name] = ""
+
+		case "confirm":
+			field = huh.NewConfirm().
 				Title(question.Prompt).
-				Options(options...).
-				Value(&defaultValues)
+				Accessor(&defaultBoolAccessor{question: question})
 
-			answers[question.Name] = defaultValues
+			answers[question.Name] = truthy(question.Default)
 
-		default:
-			options := make([]huh.Option[string], len(question.Options))
-			for i, opt := range question.Options {
-				options[i] = huh.NewOption(opt, opt)
+		case "list":
+			multiSelect := huh.NewMultiSelect[string]()
+			field = multiSelect.
+				Title(question.Prompt).
+				Accessor(&defaultListAccessor{question: question}).
+				Options(huhOptions(question.Options)...).
+				Limit(question.MaxItems).
+				Validate(func(selected []string) error {
+					return validateSelection(question, selected)
+				})
+
+			// RELOAD OPTIONS_BY OPTIONS WHEN THE ANSWER THEY DEPEND ON CHANGES
+			if question.OptionsBy != nil {
+				multiSelect.OptionsFunc(func() []huh.Option[string] {
+					return dependentOptions(question, previousAnswers())
+				}, dependsOn(question, previousAnswers))
 			}
 
-			field = huh.NewSelect[string]().
+			answers[question.Name] = listValue(question)
+
+		default:
+			options := huhOptions(question.Options)
+
+			selectField := huh.NewSelect[string]()
+			rebind = func() { selectField.Value(&question.Default) }
+			field = selectField.
 				Title(question.Prompt).
 				Options(options...).
 				Value(&question.Default)
 
+			if question.OptionsBy != nil {
+				selectField.OptionsFunc(func() []huh.Option[string] {
+					return dependentOptions(question, previousAnswers())
+				}, dependsOn(question, previousAnswers))
+			}
+
 			answers[question.Name] = question.Default
 		}
 
-		group := huh.NewGroup(field)
-		groupFields = append(groupFields, group)
-	}
-
-	return huh.NewForm(groupFields...), answers, nil
-}
+		// SHOW THE DESCRIPTION BELOW THE PROMPT, THE PLACEHOLDER IN EMPTY INPUTS AND THE HELP TEXT BELOW THE FIELD
+		describeField(field, question)
+		if question.Help != "" {
+			extraFields = append(extraFields, huh.NewNote().Description(question.Help))
+		}
 
-// RunSurveyWithRandomSelects runs the survey but generates random answers for select questions if runSurvey is false
-func RunSurveyWithRandomSelects(profilePath, surveyKey string, runSurvey bool) map[string]interface{} {
-	surveyValues := make(map[string]interface{})
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		// QUESTIONS ON A PAGE ARE ADDED TO THE GROUP OF THE PAGE, WHICH IS BUILT ONCE ALL FIELDS ARE KNOWN
+		if onPage(question, hidden) {
+			page, ok := pageGroups[question.Page]
+			if !ok {
+				page = &pageGroup{index: len(groupFields)}
+				pageGroups[question.Page] = page
+				pageNames = append(pageNames, question.Page)
+				groupFields = append(groupFields, nil)
+			}
+			page.fields = append(page.fields, append([]huh.Field{field}, extraFields...)...)
+			if templated {
+				page.refresh = append(page.refresh, func() {
+					refreshDefault(question, previousAnswers(), rebind)
+				})
+			}
+		} else {
+			group := huh.NewGroup(append([]huh.Field{field}, extraFields...)...)
 
-	// READ PROFILE AND SURVEY BY KEY
-	survey, _ := LoadQuestionFile(profilePath, surveyKey)
-
-	// IF SURVEY EXISTS, RUN IT
-	if len(survey) > 0 {
-		if !runSurvey {
-			// Generate random answers for select questions
-			for _, question := range survey {
-				if question.Kind == "select" && len(question.Options) > 0 {
-					randomIndex := r.Intn(len(question.Options))
-					question.Default = question.Options[randomIndex]
+			// HIDE THE QUESTION AS LONG AS ITS CONDITION IS NOT MET BY THE PREVIOUS ANSWERS
+			// AND KEEP TEMPLATED DEFAULTS IN SYNC UNTIL THE USER CHANGES THE VALUE
+			switch {
+			case question.reprompt != "":
+				// ANSWERED QUESTIONS OF VIOLATED RULES ARE ASKED AGAIN, SHOWING THE RULE MESSAGES
+				group.Description(question.reprompt)
+				if hidden != nil {
+					group.WithHideFunc(hidden)
 				}
-				surveyValues[question.Name] = question.Default
+			case question.answered:
+				group.WithHide(true)
+			case question.When != "" || templated || hidden != nil:
+				group.WithHideFunc(func() bool {
+					if hidden != nil && hidden() {
+						return true
+					}
+					answers := previousAnswers()
+					if !questionVisible(question, answers) {
+						return true
+					}
+					if templated {
+						refreshDefault(question, answers, rebind)
+					}
+					return false
+				})
 			}
-			return surveyValues
-		}
 
-		surveyQuestions, _, err := BuildSurvey(survey)
-		if err != nil {
-			log.Fatalf("ERROR BUILDING SURVEY: %v", err)
+			groupFields = append(groupFields, group)
 		}
-		log.Info("SURVEY FOUND")
 
-		// RUN THE INTERACTIVE SURVEY
-		err = surveyQuestions.Run()
-		if err != nil {
-			log.Fatalf("ERROR RUNNING SURVEY: %v", err)
+		// FOLLOW-UP QUESTIONS ARE ASKED FOR EVERY SELECTED OPTION OF A LIST
+		if question.Kind == "list" && len(question.Foreach) > 0 {
+			eachGroups, err := buildEach(question, previousAnswers, hidden)
+			if err != nil {
+				return nil, nil, fmt.Errorf("INVALID FOREACH BLOCK %s: %w", question.Name, err)
+			}
+			groupFields = append(groupFields, eachGroups...)
 		}
+	}
 
-		// SET ANWERS TO ALL VALUES
-		for _, question := range survey {
-			surveyValues[question.Name] = question.Default
-		}
+	for _, name := range pageNames {
+		page := pageGroups[name]
+		groupFields[page.index] = page.build(findPage(pages, name))
+	}
+
+	return groupFields, answers, nil
+}
+
+// describeField sets the description and placeholder of q on the huh field, placeholders are only shown by inputs
+func describeField(field huh.Field, q *Question) {
+	switch f := field.(type) {
+	case *huh.Input:
+		f.Description(q.Description).Placeholder(q.Placeholder)
+	case *huh.Text:
+		f.Description(q.Description).Placeholder(q.Placeholder)
+	case *huh.Confirm:
+		f.Description(q.Description)
+	case *huh.Select[string]:
+		f.Description(q.Description)
+	case *huh.MultiSelect[string]:
+		f.Description(q.Description)
+	}
+}
+
+// RunSurveyWithRandomSelects runs the survey but generates random answers for select questions if runSurvey is false
+func RunSurveyWithRandomSelects(profilePath, surveyKey string, runSurvey bool) map[string]interface{} {
+	// READ PROFILE AND SURVEY BY KEY
+	survey, _ := LoadSurveyFile(profilePath, surveyKey)
 
-	} else {
+	if survey == nil || len(survey.Questions) == 0 {
 		log.Info("NO SURVEY FOUND")
+		return make(map[string]interface{})
 	}
 
-	return surveyValues
+	if runSurvey {
+		log.Info("SURVEY FOUND")
+	}
+
+	runner := NewRunner(
+		WithSurvey(survey),
+		WithInteractive(runSurvey),
+		WithRandomSelects(true),
+	)
+
+	return runLegacy(runner)
 }
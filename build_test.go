@@ -0,0 +1,94 @@
+package survey
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSurvey(t *testing.T) {
+	questions := []*Question{
+		{Name: "username", Prompt: "Name?", Kind: "ask", MinLength: 2},
+		{Name: "hostname", Prompt: "Host?", Kind: "ask", Default: "{{ .username }}-vm"},
+		{Name: "color", Prompt: "Color?", Kind: "select", Options: NewOptions("Red", "Blue")},
+		{Name: "addons", Prompt: "Addons?", Kind: "list", Options: NewOptions("a", "b"), Default: "a"},
+		{Name: "likes_coffee", Prompt: "Coffee?", Kind: "confirm", Default: "Yes"},
+		{Name: "password", Prompt: "Password?", Kind: "password", Verify: true},
+		{Name: "certificate", Prompt: "Certificate?", Kind: "text", Lines: 10},
+		{Name: "coffee_style", Prompt: "Style?", Kind: "ask", When: "likes_coffee"},
+	}
+
+	form, answers, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+	assert.Equal(t, true, answers["likes_coffee"])
+	assert.Equal(t, []string{"a"}, answers["addons"])
+	assert.Contains(t, []string{"Red", "Blue"}, questions[2].Default)
+
+	_, _, err = BuildSurvey([]*Question{{Name: "drink", Kind: "function", DefaultFunction: "notRegistered"}})
+	var fnErr *FunctionNotFoundError
+	assert.True(t, errors.As(err, &fnErr))
+
+	_, _, err = BuildSurvey([]*Question{{Name: "drink", Kind: "ask", When: "a =="}})
+	assert.Error(t, err)
+}
+
+func TestValidateInput(t *testing.T) {
+	q := &Question{Name: "username", MinLength: 2, MaxLength: 5}
+	assert.NoError(t, validateInput(q, "abc"))
+	assert.Error(t, validateInput(q, "a"))
+	assert.Error(t, validateInput(q, "abcdef"))
+
+	// A MAXLENGTH OF 0 MEANS UNLIMITED
+	assert.NoError(t, validateInput(&Question{Name: "certificate"}, "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"))
+}
+
+func TestValidateInputTypes(t *testing.T) {
+	age := &Question{Name: "age", Type: "int", Min: "18", Max: "120"}
+	assert.NoError(t, validateInput(age, "42"))
+	assert.Error(t, validateInput(age, "17"))
+	assert.Error(t, validateInput(age, "121"))
+	assert.Error(t, validateInput(age, "forty"))
+
+	timeout := &Question{Name: "timeout", Type: "duration", Min: "1s", Max: "1h"}
+	assert.NoError(t, validateInput(timeout, "90s"))
+	assert.Error(t, validateInput(timeout, "2h"))
+	assert.Error(t, validateInput(timeout, "90"))
+
+	ratio := &Question{Name: "ratio", Type: "float", Max: "1"}
+	assert.NoError(t, validateInput(ratio, "0.75"))
+	assert.Error(t, validateInput(ratio, "1.5"))
+
+	ports := &Question{Name: "ports", Type: "[]int", Min: "1", Max: "65535"}
+	assert.NoError(t, validateInput(ports, "80,443"))
+	assert.Error(t, validateInput(ports, "80,70000"))
+}
+
+func TestValidateSelection(t *testing.T) {
+	q := &Question{Name: "addons", Kind: "list", MinItems: 1, MaxItems: 2}
+	assert.NoError(t, validateSelection(q, []string{"a", "b"}))
+	assert.Error(t, validateSelection(q, nil))
+	assert.Error(t, validateSelection(q, []string{"a", "b", "c"}))
+}
+
+func TestBuildSurveyForm(t *testing.T) {
+	survey := &Survey{
+		Title:       "VM request",
+		Version:     "1.2.0",
+		Description: "Requests a virtual machine",
+		Intro:       "Answer a few questions about your VM.",
+		Outro:       "The VM will be created after submitting.",
+		Questions:   []*Question{{Name: "name", Prompt: "Name?", Kind: "ask"}},
+	}
+
+	form, _, err := BuildSurveyForm(survey)
+	assert.NoError(t, err)
+	form.Init()
+
+	// THE INTRO IS THE FIRST SCREEN OF THE FORM
+	view := form.View()
+	assert.Contains(t, view, "VM request 1.2.0")
+	assert.Contains(t, view, "Answer a few questions about your VM.")
+	assert.NotContains(t, view, "Name?")
+}
@@ -0,0 +1,278 @@
+package survey
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DecodeError lists the struct fields Decode could not fill
+type DecodeError struct {
+	Missing    []string // Answers not found for required fields
+	Mismatched []string // Answers which could not be converted to the field type
+}
+
+func (e *DecodeError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "MISSING ANSWERS FOR "+strings.Join(e.Missing, ", "))
+	}
+	problems = append(problems, e.Mismatched...)
+	return "DECODING ANSWERS FAILED: " + strings.Join(problems, "; ")
+}
+
+// Decode maps answers into the struct pointed to by out. Fields are matched by their tag
+// `survey:"name"` (dotted names are looked up in nested answers), fields without tag are ignored
+// and fields tagged `survey:"name,optional"` may be missing. String answers are converted like
+// ConvertToType, so "25" decodes into an int and "Yes" into a bool.
+func Decode(answers map[string]interface{}, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("DECODE TARGET MUST BE A NON-NIL POINTER TO A STRUCT, GOT %T", out)
+	}
+
+	decodeErr := &DecodeError{}
+	decodeStruct(answers, rv.Elem(), "", decodeErr)
+
+	if len(decodeErr.Missing) > 0 || len(decodeErr.Mismatched) > 0 {
+		return decodeErr
+	}
+	return nil
+}
+
+// decodeStruct fills the tagged fields of rv, prefix is prepended to the names of nested structs
+func decodeStruct(answers map[string]interface{}, rv reflect.Value, prefix string, decodeErr *DecodeError) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("survey")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		name = prefix + name
+
+		value, found := lookupAnswer(answers, name)
+
+		// NESTED STRUCTS MAY ALSO BE ANSWERED BY FLAT DOTTED NAMES
+		if !found && field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			decodeStruct(answers, rv.Field(i), name+".", decodeErr)
+			continue
+		}
+
+		if !found || value == nil {
+			if _, optional := opts["optional"]; !optional {
+				decodeErr.Missing = append(decodeErr.Missing, name)
+			}
+			continue
+		}
+
+		if err := setValue(rv.Field(i), value); err != nil {
+			decodeErr.Mismatched = append(decodeErr.Mismatched, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue converts value to the type of target and stores it
+func setValue(target reflect.Value, value interface{}) error {
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return nil
+	}
+
+	if target.Type() == durationType {
+		switch v := value.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("cannot convert %q to duration", v)
+			}
+			target.SetInt(int64(d))
+			return nil
+		case time.Duration:
+			target.SetInt(int64(v))
+			return nil
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		ptr := reflect.New(target.Type().Elem())
+		if err := setValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		target.Set(ptr)
+		return nil
+
+	case reflect.String:
+		target.SetString(toString(value))
+		return nil
+
+	case reflect.Bool:
+		if b, ok := value.(bool); ok {
+			target.SetBool(b)
+			return nil
+		}
+		b, err := parseBool(toString(value))
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to bool", toString(value))
+		}
+		target.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(toString(value)), 10, 64)
+		if err != nil || target.OverflowInt(n) {
+			return fmt.Errorf("cannot convert %q to %s", toString(value), target.Type())
+		}
+		target.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(toString(value)), 10, 64)
+		if err != nil || target.OverflowUint(n) {
+			return fmt.Errorf("cannot convert %q to %s", toString(value), target.Type())
+		}
+		target.SetUint(n)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := toNumber(value)
+		if !ok {
+			return fmt.Errorf("cannot convert %q to %s", toString(value), target.Type())
+		}
+		target.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		items := toSlice(value)
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), item); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		target.Set(slice)
+		return nil
+
+	case reflect.Struct:
+		nested, ok := toStringMap(value)
+		if !ok {
+			return fmt.Errorf("cannot convert %v to %s", value, target.Type())
+		}
+		nestedErr := &DecodeError{}
+		decodeStruct(nested, target, "", nestedErr)
+		if len(nestedErr.Missing) > 0 || len(nestedErr.Mismatched) > 0 {
+			return nestedErr
+		}
+		return nil
+
+	case reflect.Map:
+		nested, ok := toStringMap(value)
+		if !ok || target.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot convert %v to %s", value, target.Type())
+		}
+		m := reflect.MakeMapWithSize(target.Type(), len(nested))
+		keys := make([]string, 0, len(nested))
+		for key := range nested {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			item := reflect.New(target.Type().Elem()).Elem()
+			if err := setValue(item, nested[key]); err != nil {
+				return fmt.Errorf("key %s: %w", key, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), item)
+		}
+		target.Set(m)
+		return nil
+	}
+
+	return fmt.Errorf("cannot convert %v to %s", value, target.Type())
+}
+
+// toSlice returns the items of a list answer, comma separated strings are split
+func toSlice(value interface{}) []interface{} {
+	if s, ok := value.(string); ok {
+		if s == "" {
+			return nil
+		}
+		parts := strings.Split(s, ",")
+		items := make([]interface{}, len(parts))
+		for i, part := range parts {
+			items[i] = strings.TrimSpace(part)
+		}
+		return items
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []interface{}{value}
+	}
+
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
+
+// toStringMap converts nested answers (as decoded by yaml.v2 or yaml.v3) to map[string]interface{}
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case Answers:
+		return m, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for key, item := range m {
+			converted[toString(key)] = item
+		}
+		return converted, true
+	}
+	return nil, false
+}
+
+// parseTag splits a struct tag like `name,prompt='Hello, world',min=2` into the name and its options
+func parseTag(tag string) (string, map[string]string) {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+
+	for _, r := range tag {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	parts = append(parts, current.String())
+
+	opts := make(map[string]string)
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(part, "=")
+		opts[strings.TrimSpace(key)] = value
+	}
+
+	return strings.TrimSpace(parts[0]), opts
+}
@@ -0,0 +1,72 @@
+package survey
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type vmConfig struct {
+	Username    string        `survey:"username"`
+	Age         int           `survey:"age"`
+	LikesCoffee bool          `survey:"likes_coffee"`
+	Timeout     time.Duration `survey:"timeout"`
+	Ratio       float64       `survey:"ratio,optional"`
+	Services    []string      `survey:"services"`
+	Ports       []int         `survey:"ports"`
+	VLAN        int           `survey:"vm.network.vlan"`
+	Comment     *string       `survey:"comment,optional"`
+	Ignored     string
+}
+
+func TestDecode(t *testing.T) {
+	answers := map[string]interface{}{
+		"username":     "patrick",
+		"age":          "25",
+		"likes_coffee": "Yes",
+		"timeout":      "1m30s",
+		"services":     []string{"web", "db"},
+		"ports":        "80, 443",
+		"vm": map[string]interface{}{
+			"network": map[interface{}]interface{}{"vlan": 42},
+		},
+		"comment": "hello",
+	}
+
+	var cfg vmConfig
+	assert.NoError(t, Decode(answers, &cfg))
+	assert.Equal(t, "patrick", cfg.Username)
+	assert.Equal(t, 25, cfg.Age)
+	assert.True(t, cfg.LikesCoffee)
+	assert.Equal(t, 90*time.Second, cfg.Timeout)
+	assert.Equal(t, []string{"web", "db"}, cfg.Services)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, 42, cfg.VLAN)
+	assert.Equal(t, "hello", *cfg.Comment)
+	assert.Equal(t, 0.0, cfg.Ratio)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var cfg vmConfig
+	err := Decode(map[string]interface{}{
+		"username":     "patrick",
+		"age":          "old",
+		"likes_coffee": "maybe",
+		"timeout":      "soon",
+		"ports":        []interface{}{80, "https"},
+	}, &cfg)
+
+	var decodeErr *DecodeError
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, []string{"services", "vm.network.vlan"}, decodeErr.Missing)
+	assert.Equal(t, []string{
+		`age: cannot convert "old" to int`,
+		`likes_coffee: cannot convert "maybe" to bool`,
+		`timeout: cannot convert "soon" to duration`,
+		`ports: item 1: cannot convert "https" to int`,
+	}, decodeErr.Mismatched)
+
+	assert.Error(t, Decode(map[string]interface{}{}, cfg))
+}
@@ -0,0 +1,157 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateDocs renders the questions of a survey as a markdown reference: title and description of the survey,
+// a table per page with name, prompt,
+// kind, type, default and description of every question, followed by the help texts and the rules.
+func GenerateDocs(survey *Survey) []byte {
+	var buf bytes.Buffer
+
+	if heading := survey.heading(); heading != "" {
+		fmt.Fprintf(&buf, "# %s\n\n", heading)
+	}
+	if survey.Description != "" {
+		fmt.Fprintf(&buf, "%s\n\n", strings.TrimSpace(survey.Description))
+	}
+
+	questions := orderPages(survey.Questions, survey.Pages)
+	for start := 0; start < len(questions); {
+		end := start + 1
+		for end < len(questions) && questions[end].Page == questions[start].Page {
+			end++
+		}
+
+		if name := questions[start].Page; name != "" {
+			page := findPage(survey.Pages, name)
+			fmt.Fprintf(&buf, "## %s\n\n", page.title())
+			if page.Description != "" {
+				fmt.Fprintf(&buf, "%s\n\n", page.Description)
+			}
+		}
+
+		buf.WriteString("| Name | Prompt | Kind | Type | Default | Description |\n")
+		buf.WriteString("|------|--------|------|------|---------|-------------|\n")
+		for _, question := range questions[start:end] {
+			writeDocsRows(&buf, question)
+		}
+		buf.WriteString("\n")
+		start = end
+	}
+
+	first := true
+	forEachDocumented(survey.Questions, "", func(q *Question, name string) {
+		if q.Help == "" {
+			return
+		}
+		if first {
+			buf.WriteString("## Help\n\n")
+			first = false
+		}
+		fmt.Fprintf(&buf, "### %s\n\n%s\n\n", name, strings.TrimSpace(q.Help))
+	})
+
+	if len(survey.Rules) > 0 {
+		buf.WriteString("## Rules\n\n")
+		for _, rule := range survey.Rules {
+			fmt.Fprintf(&buf, "- `%s`: %s\n", rule.Expr, rule.message())
+		}
+		buf.WriteString("\n")
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+// writeDocsRows writes the table row of q and the rows of its repeat and foreach questions
+func writeDocsRows(buf *bytes.Buffer, q *Question) {
+	forEachDocumented([]*Question{q}, "", func(q *Question, name string) {
+		kind := q.Kind
+		if kind == "" {
+			kind = "select"
+		}
+		fmt.Fprintf(buf, "| `%s` | %s | %s | %s | %s | %s |\n",
+			name, docsCell(q.Prompt), kind, q.Type, docsCell(docsDefault(q)), docsCell(q.Description))
+	})
+}
+
+// forEachDocumented calls fn for questions and their repeat and foreach questions with the name used in
+// the answers, e.g. disks[].size or services_each.<option>.port
+func forEachDocumented(questions []*Question, prefix string, fn func(q *Question, name string)) {
+	for _, question := range questions {
+		name := prefix + question.Name
+		fn(question, name)
+		forEachDocumented(question.Repeat, name+"[].", fn)
+		forEachDocumented(question.Foreach, prefix+eachName(question)+".<option>.", fn)
+	}
+}
+
+// docsDefault returns the default shown in the docs, secret defaults are redacted
+func docsDefault(q *Question) string {
+	value := q.Default
+	if q.defaultTemplate != "" {
+		value = q.defaultTemplate
+	}
+	if q.DefaultFunction != "" && value == "" {
+		value = q.DefaultFunction + "()"
+	}
+	if isSecret(q) && value != "" {
+		return Secret(value).String()
+	}
+	if value != "" {
+		return "`" + value + "`"
+	}
+	return ""
+}
+
+// docsCell escapes a table cell, line breaks are kept as <br>
+func docsCell(value string) string {
+	value = strings.ReplaceAll(strings.TrimSpace(value), "|", "\\|")
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
+
+// ExportAnswersDocumented marshals answers as YAML with the prompt and description of each question as comment,
+// secret answers are redacted
+func ExportAnswersDocumented(questions []*Question, answers map[string]interface{}) ([]byte, error) {
+	data, err := yaml.Marshal(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil
+	}
+	commentAnswers(doc.Content[0], questions, "")
+	return yaml.Marshal(&doc)
+}
+
+// commentAnswers sets the comments of the answers in mapping, nested answers of dotted names are found by prefix
+func commentAnswers(mapping *yaml.Node, questions []*Question, prefix string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		name := prefix + key.Value
+
+		for _, question := range questions {
+			if question.Name != name {
+				continue
+			}
+			key.HeadComment = question.Prompt
+			if question.Description != "" {
+				key.HeadComment += "\n" + question.Description
+			}
+		}
+
+		if value.Kind == yaml.MappingNode {
+			commentAnswers(value, questions, name+".")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package survey
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/huh"
+	"github.com/stretchr/testify/assert"
+)
+
+var docsYAML = `
+vm:
+  questions:
+    - prompt: "Name of the VM?"
+      name: "name"
+      kind: "ask"
+      default: "web"
+      placeholder: "e.g. web01"
+      page: "general"
+    - prompt: "Size of the root volume in %?"
+      name: "lvm.root"
+      kind: "ask"
+      type: "int"
+      default: "40"
+      description: "Share of the volume group | rest goes to /data"
+      help: |
+        The root volume holds the OS and logs,
+        keep at least 20% for updates.
+    - prompt: "Admin password?"
+      name: "password"
+      kind: "password"
+      default: "changeme"
+    - prompt: "How many disks?"
+      name: "disks"
+      kind: "repeat"
+      repeat:
+        - prompt: "Size in GB?"
+          name: "size"
+          kind: "ask"
+          type: "int"
+  pages:
+    - name: "general"
+      title: "General"
+      description: "Basic settings"
+  rules:
+    - expr: "lvm.root <= 80"
+      message: "KEEP SPACE FOR /data"
+`
+
+func TestGenerateDocs(t *testing.T) {
+	filename := createTempYAMLFile(t, docsYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	survey, err := LoadSurveyFile(filename, "vm")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "## General\n\n"+
+		"Basic settings\n\n"+
+		"| Name | Prompt | Kind | Type | Default | Description |\n"+
+		"|------|--------|------|------|---------|-------------|\n"+
+		"| `name` | Name of the VM? | ask |  | `web` |  |\n\n"+
+		"| Name | Prompt | Kind | Type | Default | Description |\n"+
+		"|------|--------|------|------|---------|-------------|\n"+
+		"| `lvm.root` | Size of the root volume in %? | ask | int | `40` | Share of the volume group \\| rest goes to /data |\n"+
+		"| `password` | Admin password? | password |  | ******** |  |\n"+
+		"| `disks` | How many disks? | repeat |  |  |  |\n"+
+		"| `disks[].size` | Size in GB? | ask | int |  |  |\n\n"+
+		"## Help\n\n"+
+		"### lvm.root\n\n"+
+		"The root volume holds the OS and logs,\nkeep at least 20% for updates.\n\n"+
+		"## Rules\n\n"+
+		"- `lvm.root <= 80`: KEEP SPACE FOR /data", string(GenerateDocs(survey)))
+
+	// THE DOCS START WITH TITLE, VERSION AND DESCRIPTION OF THE SURVEY
+	survey.Title, survey.Version, survey.Description = "VM request", "1.2.0", "Requests a virtual machine"
+	assert.True(t, strings.HasPrefix(string(GenerateDocs(survey)), "# VM request 1.2.0\n\nRequests a virtual machine\n\n## General"))
+}
+
+func TestExportAnswersDocumented(t *testing.T) {
+	questions := []*Question{
+		{Name: "name", Prompt: "Name of the VM?"},
+		{Name: "lvm.root", Prompt: "Size of the root volume in %?", Description: "Share of the volume group"},
+		{Name: "password", Prompt: "Admin password?", Kind: "password"},
+	}
+
+	data, err := ExportAnswersDocumented(questions, map[string]interface{}{
+		"name":     "web",
+		"lvm":      map[string]interface{}{"root": 40},
+		"password": Secret("changeme"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `lvm:
+    # Size of the root volume in %?
+    # Share of the volume group
+    root: 40
+# Name of the VM?
+name: web
+# Admin password?
+password: '********'
+`, string(data))
+}
+
+func TestBuildSurveyDescriptions(t *testing.T) {
+	questions := []*Question{
+		{Name: "name", Prompt: "Name?", Kind: "ask", Description: "Host name of the VM", Placeholder: "web01", Help: "Lower case letters only"},
+		{Name: "size", Prompt: "Size?", Kind: "select", Options: NewOptions("s", "m"), Description: "Sizing of the VM"},
+		{Name: "disks", Prompt: "Disks?", Kind: "repeat", Help: "Additional disks", Repeat: []*Question{{Name: "size", Kind: "ask"}}},
+	}
+
+	form, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+
+	input := huh.NewInput().Title(questions[0].Prompt)
+	describeField(input, questions[0])
+	assert.Contains(t, input.View(), "Host name of the VM")
+	assert.Contains(t, input.View(), "web01")
+
+	err = ValidateQuestions([]*Question{{Name: "size", Kind: "select", Placeholder: "m"}})
+	assert.EqualError(t, err, "1 PROBLEM(S) FOUND IN QUESTIONS:\nsize: placeholder is only shown by ask, function, password and text questions")
+}
@@ -0,0 +1,58 @@
+package survey
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// ErrUserAborted is returned if the user exits the form before submitting it
+var ErrUserAborted = huh.ErrUserAborted
+
+// KeyNotFoundError is returned if the survey key does not exist in the question file
+type KeyNotFoundError struct {
+	Key string
+}
+
+func (e *KeyNotFoundError) Error() string {
+	return fmt.Sprintf("key '%s' not found in YAML file", e.Key)
+}
+
+// FunctionNotFoundError is returned if a question references a function that was never registered
+type FunctionNotFoundError struct {
+	Name string
+	Kind string // Registry the function was looked up in, "DEFAULT", "OPTIONS" or "VALIDATOR"
+}
+
+func (e *FunctionNotFoundError) Error() string {
+	return fmt.Sprintf("%s FUNCTION %s NOT FOUND", e.Kind, e.Name)
+}
+
+// ValidationError is returned if an answer does not satisfy the constraints of its question
+type ValidationError struct {
+	Question string
+	Value    interface{}
+	Err      error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("INVALID ANSWER %v FOR %s: %v", e.Value, e.Question, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// RuleError is returned if the answers violate rules of the survey
+type RuleError struct {
+	Rules []Rule
+}
+
+func (e *RuleError) Error() string {
+	messages := make([]string, len(e.Rules))
+	for i, rule := range e.Rules {
+		messages[i] = rule.message()
+	}
+	return "ANSWERS VIOLATE RULES: " + strings.Join(messages, "; ")
+}
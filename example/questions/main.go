@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/stuttgart-things/survey"
 )
 
-var allAnswers = make(map[string]interface{})
 var runSurvey = false // Set to false to generate random answers
 
 func main() {
@@ -27,6 +27,13 @@ func main() {
 		return "water"
 	})
 
+	survey.RegisterOptionsFunction("getDesserts", func(params map[string]interface{}) []string {
+		if sweet, ok := params["sweet"].(bool); ok && !sweet {
+			return []string{"cheese", "fruit"}
+		}
+		return []string{"cake", "ice cream", "pudding"}
+	})
+
 	// LOAD THE QUESTIONS FROM YAML
 	questions, err := survey.LoadQuestionFile("questions.yaml", "survey_questions")
 	if err != nil {
@@ -39,32 +46,24 @@ func main() {
 	if !runSurvey {
 		// Generate random answers
 
-		allAnswers = survey.GetRandomAnswers(questions)
+		answers := survey.GetRandomAnswers(questions)
 
 		// Print results without type information
 		fmt.Println("Generated answers:")
-		for k, v := range allAnswers {
+		for k, v := range answers {
 			fmt.Printf("%-20s: %v\n", k, v)
 		}
 		return
 	}
 
-	// Original survey flow
-	surveyForm, _, err := survey.BuildSurvey(questions)
+	// RUN THE SURVEY OF THE FILE, CONDITIONS, COMPUTED QUESTIONS, VALIDATION AND RULES ARE HANDLED BY THE RUNNER
+	answers, err := survey.NewRunner(survey.WithQuestionFile("questions.yaml", "survey_questions")).Run(context.Background())
 	if err != nil {
-		log.Fatalf("Error building survey: %v", err)
-	}
-
-	if err := surveyForm.Run(); err != nil {
 		log.Fatalf("Error running survey: %v", err)
 	}
 
-	for _, q := range questions {
-		allAnswers[q.Name] = survey.ConvertToType(q.Default, q.Type)
-	}
-
 	fmt.Println("Survey answers:")
-	for k, v := range allAnswers {
+	for k, v := range answers {
 		fmt.Printf("%-20s: %v\n", k, v)
 	}
 }
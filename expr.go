@@ -0,0 +1,659 @@
+package survey
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EXPRESSIONS ARE SMALL BOOLEAN/ARITHMETIC FORMULAS OVER ANSWERS, E.G.
+//
+//	manage_filesystem == true && lvm_var_sizing > 10
+//
+// IDENTIFIERS ARE LOOKED UP IN THE ANSWERS MAP (DOTTED NAMES WALK NESTED MAPS),
+// UNKNOWN IDENTIFIERS EVALUATE TO nil.
+
+// EvalCondition evaluates expr against answers and reports whether it is truthy
+func EvalCondition(expr string, answers map[string]interface{}) (bool, error) {
+	value, err := EvalExpression(expr, answers)
+	if err != nil {
+		return false, err
+	}
+	return truthy(value), nil
+}
+
+// EvalExpression evaluates expr against answers and returns the resulting value
+func EvalExpression(expr string, answers map[string]interface{}) (interface{}, error) {
+	node, err := parseExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	return node.eval(answers)
+}
+
+// parseExpression parses expr into an evaluable tree
+func parseExpression(expr string) (exprNode, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d in expression %q", tok.text, tok.pos, expr)
+	}
+	return node, nil
+}
+
+// TOKENIZER
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ","}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '"' || c == '\'':
+			quote := c
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != quote; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d in expression %q", i, expr)
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: i})
+			i = j + 1
+
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[i:j]), pos: i})
+			i = j
+
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[i:j]), pos: i})
+			i = j
+
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{kind: tokOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d in expression %q", c, i, expr)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// PARSER (PRECEDENCE: || < && < COMPARISON/in < +,- < *,/,% < UNARY)
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOperator && tok.kind != tokIdent {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: "||", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">", "in")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op, ok := p.accept("!", "-", "not"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "not" {
+			op = "!"
+		}
+		return unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return literalNode{value: normalizeNumber(f)}, nil
+
+	case tokString:
+		return literalNode{value: tok.text}, nil
+
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "nil", "null":
+			return literalNode{value: nil}, nil
+		}
+		return identNode{name: tok.text}, nil
+
+	case tokOperator:
+		switch tok.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("missing ')' at position %d", p.peek().pos)
+			}
+			return node, nil
+
+		case "[":
+			var items []exprNode
+			if _, ok := p.accept("]"); ok {
+				return listNode{items: items}, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+				if _, ok := p.accept(","); ok {
+					continue
+				}
+				if _, ok := p.accept("]"); !ok {
+					return nil, fmt.Errorf("missing ']' at position %d", p.peek().pos)
+				}
+				return listNode{items: items}, nil
+			}
+		}
+	}
+
+	if tok.kind == tokEOF {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+// EVALUATION
+
+type exprNode interface {
+	eval(answers map[string]interface{}) (interface{}, error)
+}
+
+type literalNode struct{ value interface{} }
+
+type identNode struct{ name string }
+
+type listNode struct{ items []exprNode }
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n identNode) eval(answers map[string]interface{}) (interface{}, error) {
+	value, _ := lookupAnswer(answers, n.name)
+	return value, nil
+}
+
+func (n listNode) eval(answers map[string]interface{}) (interface{}, error) {
+	values := make([]interface{}, 0, len(n.items))
+	for _, item := range n.items {
+		v, err := item.eval(answers)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (n unaryNode) eval(answers map[string]interface{}) (interface{}, error) {
+	v, err := n.operand.eval(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "!" {
+		return !truthy(v), nil
+	}
+
+	f, ok := toNumber(v)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate non-numeric value %v", v)
+	}
+	return normalizeNumber(-f), nil
+}
+
+func (n binaryNode) eval(answers map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	// SHORT-CIRCUIT LOGICAL OPERATORS
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(answers)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(right), nil
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(answers)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(right), nil
+	}
+
+	right, err := n.right.eval(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return looseEqual(left, right), nil
+	case "!=":
+		return !looseEqual(left, right), nil
+	case "in":
+		return contains(right, left), nil
+	case "<", "<=", ">", ">=":
+		return compare(n.op, left, right)
+	case "+":
+		lf, lok := toNumber(left)
+		rf, rok := toNumber(right)
+		if lok && rok {
+			return normalizeNumber(lf + rf), nil
+		}
+		return toString(left) + toString(right), nil
+	default:
+		lf, lok := toNumber(left)
+		rf, rok := toNumber(right)
+		if !lok || !rok {
+			return nil, fmt.Errorf("operator %s needs numeric operands, got %v and %v", n.op, left, right)
+		}
+		switch n.op {
+		case "-":
+			return normalizeNumber(lf - rf), nil
+		case "*":
+			return normalizeNumber(lf * rf), nil
+		case "/":
+			if rf == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return normalizeNumber(lf / rf), nil
+		case "%":
+			if rf == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return normalizeNumber(math.Mod(lf, rf)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+// lookupAnswer resolves name in answers, first as a flat key and then as a dotted path through nested maps
+func lookupAnswer(answers map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := answers[name]; ok {
+		return value, true
+	}
+
+	parts := strings.Split(name, ".")
+	var current interface{} = answers
+	for _, part := range parts {
+		switch m := current.(type) {
+		case map[string]interface{}:
+			v, ok := m[part]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		case map[interface{}]interface{}:
+			v, ok := m[part]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// truthy reports whether an answer counts as "yes"
+func truthy(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case Secret:
+		return t != ""
+	case string:
+		switch strings.ToLower(strings.TrimSpace(t)) {
+		case "", "false", "no", "n", "off", "0":
+			return false
+		}
+		return true
+	}
+
+	if f, ok := toNumber(v); ok {
+		return f != 0
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() > 0
+	}
+	return true
+}
+
+// toNumber converts numeric values and numeric strings to float64
+func toNumber(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case int:
+		return float64(t), true
+	case int8:
+		return float64(t), true
+	case int16:
+		return float64(t), true
+	case int32:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case uint:
+		return float64(t), true
+	case uint8:
+		return float64(t), true
+	case uint16:
+		return float64(t), true
+	case uint32:
+		return float64(t), true
+	case uint64:
+		return float64(t), true
+	case float32:
+		return float64(t), true
+	case float64:
+		return t, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// normalizeNumber returns whole numbers as int so results compare and print naturally
+func normalizeNumber(f float64) interface{} {
+	if f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return int(f)
+	}
+	return f
+}
+
+// toString formats v for comparisons and conversions, secrets are revealed
+func toString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case Secret:
+		return t.Reveal()
+	}
+	return fmt.Sprint(v)
+}
+
+// looseEqual compares answers the way survey authors expect: "25" == 25 and "Yes" == true
+func looseEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if af, ok := toNumber(a); ok {
+		if bf, ok := toNumber(b); ok {
+			return af == bf
+		}
+	}
+
+	_, aBool := a.(bool)
+	_, bBool := b.(bool)
+	if aBool || bBool {
+		return truthy(a) == truthy(b)
+	}
+
+	if reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() {
+		return a == b
+	}
+	return toString(a) == toString(b)
+}
+
+func compare(op string, left, right interface{}) (bool, error) {
+	lf, lok := toNumber(left)
+	rf, rok := toNumber(right)
+
+	var cmp int
+	switch {
+	case lok && rok:
+		switch {
+		case lf < rf:
+			cmp = -1
+		case lf > rf:
+			cmp = 1
+		}
+	case left == nil || right == nil:
+		return false, nil
+	default:
+		cmp = strings.Compare(toString(left), toString(right))
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+// contains implements the "in" operator for lists, maps and substrings
+func contains(collection, item interface{}) bool {
+	if s, ok := collection.(string); ok {
+		return strings.Contains(s, toString(item))
+	}
+
+	rv := reflect.ValueOf(collection)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if looseEqual(rv.Index(i).Interface(), item) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			if looseEqual(key.Interface(), item) {
+				return true
+			}
+		}
+	}
+	return false
+}
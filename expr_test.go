@@ -0,0 +1,92 @@
+package survey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalCondition(t *testing.T) {
+	answers := map[string]interface{}{
+		"manage_filesystem": "true",
+		"likes_coffee":      "No",
+		"age":               "25",
+		"count":             3,
+		"language":          "Go",
+		"services":          []string{"web", "db"},
+		"vm": map[string]interface{}{
+			"network": map[string]interface{}{"vlan": 42},
+		},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{"bare identifier", "manage_filesystem", true},
+		{"string no is falsy", "likes_coffee", false},
+		{"negation", "!likes_coffee", true},
+		{"compare with bool", "manage_filesystem == true", true},
+		{"numeric string compare", "age > 18", true},
+		{"numeric equality across types", "age == 25", true},
+		{"string equality", `language == "Go"`, true},
+		{"string inequality", `language != 'Go'`, false},
+		{"and", "manage_filesystem && age >= 30", false},
+		{"or", "likes_coffee || count == 3", true},
+		{"word operators", "not likes_coffee and count > 2", true},
+		{"arithmetic", "count * 2 + 1 == 7", true},
+		{"parentheses", "(count + 1) * 2 == 8", true},
+		{"unknown identifier", "missing", false},
+		{"unknown identifier equals nil", "missing == nil", true},
+		{"in list answer", `"db" in services`, true},
+		{"in list literal", `language in ["Go", "Rust"]`, true},
+		{"dotted path", "vm.network.vlan == 42", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalCondition(tt.expr, answers)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvalExpression(t *testing.T) {
+	answers := map[string]interface{}{
+		"hostname": "web01",
+		"domain":   "example.com",
+		"lvm_home": "30",
+		"lvm_root": 40,
+	}
+
+	value, err := EvalExpression(`hostname + "." + domain`, answers)
+	assert.NoError(t, err)
+	assert.Equal(t, "web01.example.com", value)
+
+	value, err = EvalExpression("lvm_home + lvm_root", answers)
+	assert.NoError(t, err)
+	assert.Equal(t, 70, value)
+
+	value, err = EvalExpression("lvm_root / 16", answers)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, value)
+}
+
+func TestEvalExpressionErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"a ==",
+		"(a == 1",
+		`name == "unterminated`,
+		"a # b",
+		"1 / 0",
+		"hostname - 1",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := EvalExpression(expr, map[string]interface{}{"hostname": "web01"})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package survey
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/huh"
+)
+
+// eachName returns the answer name of the follow-up answers of a list question, e.g. services_each
+func eachName(q *Question) string {
+	return q.Name + "_each"
+}
+
+// eachInstance returns the copies of the Foreach questions of a list question asked for the option value
+func eachInstance(q *Question, value string) []*Question {
+	if children, ok := q.each[value]; ok {
+		return children
+	}
+
+	label := value
+	for _, option := range eachOptions(q) {
+		if option.Value == value && option.Label != "" {
+			label = option.Label
+		}
+	}
+
+	children := make([]*Question, len(q.Foreach))
+	for i, child := range q.Foreach {
+		instance := *child
+		instance.Prompt = fmt.Sprintf("%s: %s", label, child.Prompt)
+		instance.instances = nil
+		instance.each = nil
+		children[i] = &instance
+	}
+
+	if q.each == nil {
+		q.each = make(map[string][]*Question)
+	}
+	q.each[value] = children
+	return children
+}
+
+// eachOptions returns every option a list question can offer, with options_by the options mapped to
+// any answer since the options only become known while the form runs
+func eachOptions(q *Question) []Option {
+	if q.OptionsBy == nil {
+		return q.Options
+	}
+
+	keys := make([]string, 0, len(q.OptionsBy.Map))
+	for key := range q.OptionsBy.Map {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	options := append([]Option(nil), q.Options...)
+	for _, key := range keys {
+		for _, option := range q.OptionsBy.Map[key] {
+			if !contains(optionValues(options), option.Value) {
+				options = append(options, option)
+			}
+		}
+	}
+	return options
+}
+
+// eachItems returns the follow-up answers of a list question by selected option. answer fills in the answers
+// of the child questions of an option, given a copy of the answers so far as scope.
+func eachItems(q *Question, answers map[string]interface{}, answer func(children []*Question, scope map[string]interface{}) error) (map[string]interface{}, error) {
+	items := make(map[string]interface{})
+
+	for _, value := range listItems(q.Default) {
+		item, err := scopedItem(eachInstance(q, value), answers, answer)
+		if err != nil {
+			return nil, err
+		}
+		items[value] = item
+	}
+
+	return items, nil
+}
+
+// presetEach presets the follow-up questions of a list question from a map of answers by option value
+func presetEach(q *Question, value interface{}) {
+	items, ok := toStringMap(value)
+	if !ok {
+		return
+	}
+	for option, item := range items {
+		if answers, ok := toStringMap(item); ok {
+			ApplyAnswers(eachInstance(q, option), answers)
+		}
+	}
+}
+
+// buildEach builds the groups of the follow-up questions of every option of a list question,
+// the questions of an option are hidden as long as the option is not selected
+func buildEach(q *Question, previous func() map[string]interface{}, hidden func() bool) ([]*huh.Group, error) {
+	scope := func() map[string]interface{} {
+		answers := previous()
+		answers[q.Name] = answerValue(q)
+		return answers
+	}
+
+	var groups []*huh.Group
+	for _, option := range eachOptions(q) {
+		value := option.Value
+		children := eachInstance(q, value)
+
+		// A VIOLATED RULE ON THE LIST PROMPTS THE FOLLOW-UP QUESTIONS AGAIN
+		if q.reprompt != "" {
+			forEachQuestion(children, func(child *Question) {
+				child.reprompt = q.reprompt
+			})
+		}
+
+		optionGroups, _, err := buildGroups(children, scope, func() bool {
+			return (hidden != nil && hidden()) || !questionVisible(q, previous()) || !contains(listItems(q.Default), value)
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, optionGroups...)
+	}
+
+	return groups, nil
+}
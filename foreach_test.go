@@ -0,0 +1,153 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var foreachYAML = `
+cluster:
+  - prompt: "Which services?"
+    name: "services"
+    kind: "list"
+    default: "web,db"
+    options:
+      - label: "Web server"
+        value: "web"
+      - value: "db"
+      - value: "cache"
+    foreach:
+      - prompt: "Port?"
+        name: "port"
+        kind: "ask"
+        type: "int"
+        default: "8080"
+        min: 1
+        max: 65535
+      - prompt: "Replicas?"
+        name: "replicas"
+        kind: "ask"
+        type: "int"
+        default: "1"
+`
+
+func TestRunnerForeach(t *testing.T) {
+	filename := createTempYAMLFile(t, foreachYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFileStrict(filename, "cluster")
+	assert.NoError(t, err)
+	assert.Len(t, questions[0].Foreach, 2)
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web", "db"}, answers["services"])
+	assert.Equal(t, map[string]interface{}{
+		"web": map[string]interface{}{"port": 8080, "replicas": 1},
+		"db":  map[string]interface{}{"port": 8080, "replicas": 1},
+	}, answers["services_each"])
+
+	// FOLLOW-UP ANSWERS ARE PRESET BY OPTION, MISSING ANSWERS USE THE DEFAULTS
+	questions, err = LoadQuestionFile(filename, "cluster")
+	assert.NoError(t, err)
+	answers, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{
+		"services": []interface{}{"db", "cache"},
+		"services_each": map[string]interface{}{
+			"db":    map[string]interface{}{"port": 5432},
+			"cache": map[string]interface{}{"port": 6379, "replicas": 3},
+		},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"db":    map[string]interface{}{"port": 5432, "replicas": 1},
+		"cache": map[string]interface{}{"port": 6379, "replicas": 3},
+	}, answers["services_each"])
+
+	// THE FOLLOW-UP ANSWERS OF EVERY OPTION ARE VALIDATED
+	questions, err = LoadQuestionFile(filename, "cluster")
+	assert.NoError(t, err)
+	_, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{
+		"services_each": map[string]interface{}{"db": map[string]interface{}{"port": 0}},
+	}), WithInteractive(false)).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "services_each[db].port", validationErr.Question)
+}
+
+func TestBuildSurveyForeach(t *testing.T) {
+	filename := createTempYAMLFile(t, foreachYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFile(filename, "cluster")
+	assert.NoError(t, err)
+
+	form, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+	assert.Len(t, questions[0].each, 3)
+	assert.Equal(t, "Web server: Port?", questions[0].each["web"][0].Prompt)
+	assert.Equal(t, "cache: Port?", questions[0].each["cache"][0].Prompt)
+
+	// ONLY THE FOLLOW-UP QUESTIONS OF SELECTED OPTIONS ARE COLLECTED
+	questions[0].Default = "cache"
+	questions[0].each["cache"][0].Default = "6379"
+	assert.Equal(t, map[string]interface{}{
+		"cache": map[string]interface{}{"port": 6379, "replicas": 1},
+	}, collectAnswers(questions)["services_each"])
+}
+
+func TestGetRandomAnswersForeach(t *testing.T) {
+	questions := []*Question{{
+		Name:     "services",
+		Kind:     "list",
+		Options:  []Option{{Value: "web"}, {Value: "db"}},
+		MinItems: 1,
+		Foreach: []*Question{
+			{Name: "port", Kind: "ask", Type: "int", Min: "1", Max: "1024"},
+		},
+	}}
+
+	answers := GetRandomAnswers(questions)
+	services, _ := answers["services"].([]string)
+	each, _ := answers["services_each"].(map[string]interface{})
+	assert.Len(t, each, len(services))
+	for _, service := range services {
+		item, _ := each[service].(map[string]interface{})
+		if port, ok := item["port"].(int); !ok || port < 1 || port > 1024 {
+			t.Errorf("GetRandomAnswers() port of %s = %v, want 1..1024", service, item["port"])
+		}
+	}
+}
+
+func TestBuildSurveyForeachOptionsBy(t *testing.T) {
+	questions := []*Question{
+		{Name: "region", Kind: "select", Options: NewOptions("eu", "us"), Default: "eu"},
+		{Name: "zones", Kind: "list", OptionsBy: &OptionsBy{Field: "region", Map: map[string][]Option{
+			"eu": NewOptions("fra1", "ams1"),
+			"us": {{Label: "New York", Value: "nyc1"}},
+		}}, Foreach: []*Question{{Name: "nodes", Prompt: "Nodes?", Kind: "ask", Type: "int", Default: "1"}}},
+	}
+
+	// FOLLOW-UP QUESTIONS EXIST FOR THE OPTIONS OF EVERY REGION, NOT ONLY THE ONE ANSWERED WHEN THE FORM IS BUILT
+	form, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+	assert.Len(t, questions[1].each, 3)
+	assert.Equal(t, "New York: Nodes?", questions[1].each["nyc1"][0].Prompt)
+
+	questions[0].Default = "us"
+	questions[1].Default = "nyc1"
+	assert.Equal(t, map[string]interface{}{
+		"nyc1": map[string]interface{}{"nodes": 1},
+	}, collectAnswers(questions)["zones_each"])
+}
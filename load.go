@@ -1,13 +1,25 @@
 package survey
 
 import (
-	"fmt"
 	"os"
 
 	"gopkg.in/yaml.v2"
 )
 
+// LoadQuestionFile loads the questions stored under yamlKey (or the questions of a file holding only a list),
+// see LoadSurveyFile for question files with rules
 func LoadQuestionFile(filename, yamlKey string) ([]*Question, error) {
+	survey, err := LoadSurveyFile(filename, yamlKey)
+	if err != nil {
+		return nil, err
+	}
+	return survey.Questions, nil
+}
+
+// LoadSurveyFile loads the survey stored under yamlKey, which is either a list of questions
+// or an object with questions, rules, pages and metadata like title, intro and outro.
+// A file holding only a survey object is loaded without yamlKey.
+func LoadSurveyFile(filename, yamlKey string) (*Survey, error) {
 	var questions []*Question
 
 	// READ THE YAML FILE
@@ -18,7 +30,7 @@ func LoadQuestionFile(filename, yamlKey string) ([]*Question, error) {
 
 	// ATTEMPT TO UNMARSHAL AS A LIST DIRECTLY (FOR YAML WITHOUT `yamlKey` KEY)
 	if err := yaml.Unmarshal(data, &questions); err == nil {
-		return questions, nil
+		return &Survey{Questions: questions}, nil
 	}
 
 	// IF UNMARSHALING DIRECTLY FAILS, UNMARSHAL INTO A MAP AND EXTRACT BY `yamlKey`
@@ -34,12 +46,30 @@ func LoadQuestionFile(filename, yamlKey string) ([]*Question, error) {
 			return nil, err
 		}
 
+		// THE SURVEY IS EITHER A LIST OF QUESTIONS OR AN OBJECT WITH QUESTIONS AND RULES
+		if _, isMap := rawQuestions.(map[interface{}]interface{}); isMap {
+			survey := &Survey{}
+			if err := yaml.Unmarshal(rawData, survey); err != nil {
+				return nil, err
+			}
+			return survey, nil
+		}
+
 		if err := yaml.Unmarshal(rawData, &questions); err != nil {
 			return nil, err
 		}
-		return questions, nil
+		return &Survey{Questions: questions}, nil
+	}
+
+	// THE WHOLE FILE MAY BE A SURVEY OBJECT WITHOUT `yamlKey`
+	if _, found := genericMap["questions"]; found {
+		survey := &Survey{}
+		if err := yaml.Unmarshal(data, survey); err != nil {
+			return nil, err
+		}
+		return survey, nil
 	}
 
 	// RETURN AN ERROR IF `yamlKey` IS NOT FOUND
-	return nil, fmt.Errorf("key '%s' not found in YAML file", yamlKey)
+	return nil, &KeyNotFoundError{Key: yamlKey}
 }
@@ -1,6 +1,7 @@
 package survey
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -56,3 +57,82 @@ func createTempYAMLFile(t *testing.T, content string) string {
 
 	return tmpFile.Name()
 }
+
+func TestLoadQuestionFileOptions(t *testing.T) {
+	filename := createTempYAMLFile(t, `
+survey_questions:
+  - prompt: "VM size?"
+    name: "size"
+    kind: "select"
+    default: "s2"
+    options:
+      - label: "Small (2 CPU)"
+        value: "s2"
+        description: "for testing"
+      - value: "m4"
+      - "l8"
+  - prompt: "CPUs?"
+    name: "cpus"
+    kind: "select"
+    type: "int"
+    default: "2"
+    options:
+      - label: "Two"
+        value: 2
+`)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFile(filename, "survey_questions")
+	assert.NoError(t, err)
+	assert.Equal(t, []Option{
+		{Label: "Small (2 CPU)", Value: "s2", Description: "for testing"},
+		{Value: "m4"},
+		{Value: "l8"},
+	}, questions[0].Options)
+	assert.Equal(t, "Small (2 CPU) - for testing", questions[0].Options[0].Title())
+	assert.Equal(t, "l8", questions[0].Options[2].Title())
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "s2", answers["size"])
+	assert.Equal(t, 2, answers["cpus"])
+}
+
+func TestLoadSurveyFileMetadata(t *testing.T) {
+	filename := createTempYAMLFile(t, `
+title: "VM request"
+description: "Requests a virtual machine"
+version: "1.2.0"
+intro: "Answer a few questions about your VM."
+outro: "The VM will be created after submitting."
+questions:
+  - prompt: "Name?"
+    name: "name"
+    kind: "ask"
+    default: "web"
+`)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	// A FILE HOLDING ONLY A SURVEY OBJECT IS LOADED WITHOUT KEY
+	survey, err := LoadSurveyFile(filename, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "VM request", survey.Title)
+	assert.Equal(t, "1.2.0", survey.Version)
+	assert.Equal(t, "The VM will be created after submitting.", survey.Outro)
+	assert.Len(t, survey.Questions, 1)
+	assert.Equal(t, "VM request 1.2.0", survey.heading())
+
+	questions, err := LoadQuestionFileStrict(filename, "")
+	assert.NoError(t, err)
+	assert.Len(t, questions, 1)
+
+	answers, err := NewRunner(WithQuestionFile(filename, "vm"), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"name": "web"}, answers)
+}
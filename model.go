@@ -14,11 +14,52 @@ type Question struct {
 	Default         string                 `yaml:"default,omitempty"`
 	DefaultFunction string                 `yaml:"default_function,omitempty"`
 	DefaultParams   map[string]interface{} `yaml:"default_params,omitempty"`
-	Options         []string               `yaml:"options"`
+	Options         []Option               `yaml:"options"`
+	OptionsFunction string                 `yaml:"options_function,omitempty"`
+	OptionsParams   map[string]interface{} `yaml:"options_params,omitempty"`
+	OptionsBy       *OptionsBy             `yaml:"options_by,omitempty"`
 	Kind            string                 `yaml:"kind,omitempty"` // "function" instead of "text"
 	MinLength       int                    `yaml:"minLength,omitempty"`
 	MaxLength       int                    `yaml:"maxLength,omitempty"`
-	Type            string                 `yaml:"type,omitempty"` // Updated field to match the YAML
+	MinItems        int                    `yaml:"minItems,omitempty"`
+	MaxItems        int                    `yaml:"maxItems,omitempty"`
+	Validate        []string               `yaml:"validate,omitempty"`
+	Repeat          []*Question            `yaml:"repeat,omitempty"`
+	Foreach         []*Question            `yaml:"foreach,omitempty"`
+	Description     string                 `yaml:"description,omitempty"`
+	Placeholder     string                 `yaml:"placeholder,omitempty"`
+	Help            string                 `yaml:"help,omitempty"`
+	Min             string                 `yaml:"min,omitempty"`    // Lower bound of int, float and duration answers
+	Max             string                 `yaml:"max,omitempty"`    // Upper bound of int, float and duration answers
+	Type            string                 `yaml:"type,omitempty"`   // Updated field to match the YAML
+	When            string                 `yaml:"when,omitempty"`   // Expression over previous answers, question is skipped if false
+	Env             string                 `yaml:"env,omitempty"`    // Environment variable overriding the answer
+	Secret          bool                   `yaml:"secret,omitempty"` // Mask the input and redact the answer, implied by kind "password"
+	Verify          bool                   `yaml:"verify,omitempty"` // Ask a second time to confirm the input
+	Lines           int                    `yaml:"lines,omitempty"`  // Visible lines of a "text" question
+	Expr            string                 `yaml:"expr,omitempty"`   // Expression over previous answers computing the answer of a "computed" question
+	Page            string                 `yaml:"page,omitempty"`   // Name of the page showing the question together with the other questions of the page
+
+	defaultTemplate string // Original templated default, Default is overwritten by the rendered value
+	renderedDefault string // Last rendered default, used to detect if the user changed the value
+	answered        bool   // Answer was preset (e.g. from an answers file) and is not prompted
+	reprompt        string // Messages of the violated rules, the answered question is prompted again
+
+	instances [][]*Question          // Copies of the Repeat questions of a "repeat" question, one per entry
+	each      map[string][]*Question // Copies of the Foreach questions of a "list" question, by option value
+}
+
+// SURVEY STRUCT TO HOLD A QUESTION FILE IN OBJECT FORM
+type Survey struct {
+	Title       string `yaml:"title,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version,omitempty"`
+	Intro       string `yaml:"intro,omitempty"` // Note shown before the first question
+	Outro       string `yaml:"outro,omitempty"` // Note shown after the last question
+
+	Questions []*Question `yaml:"questions"`
+	Rules     []Rule      `yaml:"rules,omitempty"` // Checks across answers, evaluated after the form completes
+	Pages     []Page      `yaml:"pages,omitempty"` // Order, titles and descriptions of the pages
 }
 
 // MODEL HOLDS THE STATE FOR THE TERMINAL UI.
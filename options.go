@@ -0,0 +1,196 @@
+package survey
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// OptionsFunctions holds the registered functions providing options
+var OptionsFunctions = make(map[string]func(params map[string]interface{}) []string)
+
+// RegisterOptionsFunction adds a function providing the options of select and list questions,
+// e.g. kube contexts, git branches or files in a directory
+func RegisterOptionsFunction(name string, fn func(params map[string]interface{}) []string) {
+	OptionsFunctions[name] = fn
+}
+
+// OptionsBy picks the options of a question by the answer of a previous question,
+// e.g. the zones of the selected region
+type OptionsBy struct {
+	Field string              `yaml:"field"`
+	Map   map[string][]Option `yaml:"map"`
+}
+
+// options returns the options mapped to the answer of the field, none if the answer is not mapped
+func (o *OptionsBy) options(answers map[string]interface{}) []Option {
+	value, _ := lookupAnswer(answers, o.Field)
+	return o.Map[toString(value)]
+}
+
+// resolveOptions sets the options of q from its options function or options_by mapping,
+// params are rendered against answers
+func resolveOptions(q *Question, answers map[string]interface{}) error {
+	if q.OptionsBy != nil {
+		q.Options = q.OptionsBy.options(answers)
+		keepSelection(q)
+		return nil
+	}
+
+	if q.OptionsFunction == "" {
+		return nil
+	}
+
+	fn, ok := OptionsFunctions[q.OptionsFunction]
+	if !ok {
+		return &FunctionNotFoundError{Name: q.OptionsFunction, Kind: "OPTIONS"}
+	}
+
+	params, err := renderParams(q.OptionsParams, answers)
+	if err != nil {
+		return fmt.Errorf("OPTIONS PARAMS OF %s: %w", q.Name, err)
+	}
+
+	q.Options = NewOptions(fn(params)...)
+	return nil
+}
+
+// keepSelection drops the selected values which are no longer options after the options changed,
+// a select falls back to the first option. Preset answers are kept to be reported as invalid.
+func keepSelection(q *Question) {
+	if q.answered {
+		return
+	}
+
+	values := optionValues(q.Options)
+	if q.Kind == "list" {
+		var selected []string
+		for _, item := range listItems(q.Default) {
+			if contains(values, item) {
+				selected = append(selected, item)
+			}
+		}
+		q.Default = strings.Join(selected, ",")
+		return
+	}
+
+	if !contains(values, q.Default) {
+		q.Default = ""
+		if len(values) > 0 {
+			q.Default = values[0]
+		}
+	}
+}
+
+// Option is a choice of a select or list question. In YAML it is either a plain string,
+// which is label and value at once, or a mapping with label, value and description.
+type Option struct {
+	Label       string `yaml:"label,omitempty"`
+	Value       string `yaml:"value"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// NewOptions creates options whose labels are their values
+func NewOptions(values ...string) []Option {
+	options := make([]Option, len(values))
+	for i, value := range values {
+		options[i] = Option{Value: value}
+	}
+	return options
+}
+
+func (o *Option) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err == nil {
+		*o = Option{Value: value}
+		return nil
+	}
+
+	type plain Option
+	var p plain
+	if err := unmarshal(&p); err != nil {
+		return err
+	}
+
+	*o = Option(p)
+	if o.Value == "" {
+		o.Value = o.Label
+	}
+	return nil
+}
+
+func (o Option) MarshalYAML() (interface{}, error) {
+	if o.Label == "" && o.Description == "" {
+		return o.Value, nil
+	}
+
+	type plain Option
+	return plain(o), nil
+}
+
+// Title returns the text shown for the option in the form
+func (o Option) Title() string {
+	title := o.Label
+	if title == "" {
+		title = o.Value
+	}
+	if o.Description != "" {
+		title += " - " + o.Description
+	}
+	return title
+}
+
+// optionValues returns the values of options
+func optionValues(options []Option) []string {
+	values := make([]string, len(options))
+	for i, option := range options {
+		values[i] = option.Value
+	}
+	return values
+}
+
+// huhOptions converts options to the options of a huh select or multi select
+func huhOptions(options []Option) []huh.Option[string] {
+	converted := make([]huh.Option[string], len(options))
+	for i, option := range options {
+		converted[i] = huh.NewOption(option.Title(), option.Value)
+	}
+	return converted
+}
+
+// dependentOptions resolves the options_by options of q against answers for a huh options func,
+// the options of a list keep their selection
+func dependentOptions(q *Question, answers map[string]interface{}) []huh.Option[string] {
+	_ = resolveOptions(q, answers)
+
+	options := huhOptions(q.Options)
+	if q.Kind == "list" {
+		selected := listItems(q.Default)
+		for i := range options {
+			options[i] = options[i].Selected(contains(selected, options[i].Value))
+		}
+	}
+	return options
+}
+
+// answerBinding binds a huh options func to the answer of a previous question.
+// huh reloads the options whenever the hash of the binding changes.
+type answerBinding struct {
+	value func() string
+}
+
+// dependsOn returns the binding of the options_by options of q to the answer of its field
+func dependsOn(q *Question, answers func() map[string]interface{}) answerBinding {
+	return answerBinding{value: func() string {
+		value, _ := lookupAnswer(answers(), q.OptionsBy.Field)
+		return toString(value)
+	}}
+}
+
+func (b answerBinding) Hash() (uint64, error) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(b.value()))
+	return h.Sum64(), nil
+}
@@ -0,0 +1,124 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/charmbracelet/huh"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveOptions(t *testing.T) {
+	RegisterOptionsFunction("zones", func(params map[string]interface{}) []string {
+		region, _ := params["region"].(string)
+		return []string{region + "-1", region + "-2"}
+	})
+
+	q := &Question{
+		Name:            "zone",
+		Kind:            "select",
+		OptionsFunction: "zones",
+		OptionsParams:   map[string]interface{}{"region": "{{ .region }}"},
+	}
+
+	assert.NoError(t, resolveOptions(q, map[string]interface{}{"region": "eu"}))
+	assert.Equal(t, NewOptions("eu-1", "eu-2"), q.Options)
+
+	err := resolveOptions(&Question{Name: "zone", OptionsFunction: "notRegistered"}, nil)
+	var fnErr *FunctionNotFoundError
+	assert.True(t, errors.As(err, &fnErr))
+	assert.Equal(t, "OPTIONS FUNCTION notRegistered NOT FOUND", err.Error())
+
+	answers, err := NewRunner(WithQuestions([]*Question{
+		{Name: "region", Kind: "select", Options: NewOptions("us")},
+		{Name: "zone", Kind: "select", OptionsFunction: "zones", OptionsParams: map[string]interface{}{"region": "{{ .region }}"}},
+	}), WithInteractive(false), WithRandomSelects(true)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, []string{"us-1", "us-2"}, answers["zone"])
+}
+
+var optionsByYAML = `
+location:
+  - prompt: "Region?"
+    name: "region"
+    kind: "select"
+    options: ["eu", "us"]
+    default: "eu"
+  - prompt: "Zone?"
+    name: "zone"
+    kind: "select"
+    default: "ams1"
+    options_by:
+      field: "region"
+      map:
+        eu: ["fra1", "ams1"]
+        us: ["nyc1"]
+`
+
+func TestOptionsBy(t *testing.T) {
+	filename := createTempYAMLFile(t, optionsByYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFileStrict(filename, "location")
+	assert.NoError(t, err)
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ams1", answers["zone"])
+
+	// A DEFAULT WHICH IS NOT ONE OF THE MAPPED OPTIONS FALLS BACK TO THE FIRST OPTION
+	questions, err = LoadQuestionFile(filename, "location")
+	assert.NoError(t, err)
+	answers, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{"region": "us"}), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "nyc1", answers["zone"])
+
+	// PRESETS MUST BE ONE OF THE OPTIONS OF THE GIVEN ANSWER
+	questions, err = LoadQuestionFile(filename, "location")
+	assert.NoError(t, err)
+	_, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{"region": "us", "zone": "fra1"}), WithInteractive(false)).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "zone", validationErr.Question)
+
+	// RANDOM ANSWERS RESPECT THE MAPPING
+	questions, err = LoadQuestionFile(filename, "location")
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		random := GetRandomAnswers(questions)
+		zones := map[string][]string{"eu": {"fra1", "ams1"}, "us": {"nyc1"}}[random["region"].(string)]
+		assert.Contains(t, zones, random["zone"])
+	}
+}
+
+func TestDependentOptions(t *testing.T) {
+	region := &Question{Name: "region", Kind: "select", Default: "eu"}
+	zones := &Question{
+		Name:    "zones",
+		Kind:    "list",
+		Default: "fra1,nyc1",
+		OptionsBy: &OptionsBy{Field: "region", Map: map[string][]Option{
+			"eu": NewOptions("fra1", "ams1"),
+			"us": NewOptions("nyc1"),
+		}},
+	}
+	answers := func() map[string]interface{} { return collectAnswers([]*Question{region}) }
+
+	options := dependentOptions(zones, answers())
+	assert.Equal(t, []huh.Option[string]{huh.NewOption("fra1", "fra1").Selected(true), huh.NewOption("ams1", "ams1")}, options)
+	assert.Equal(t, "fra1", zones.Default)
+
+	// THE BINDING CHANGES WITH THE ANSWER, WHICH MAKES HUH RELOAD THE OPTIONS
+	binding := dependsOn(zones, answers)
+	before, _ := binding.Hash()
+	region.Default = "us"
+	after, _ := binding.Hash()
+	assert.NotEqual(t, before, after)
+	assert.Equal(t, []huh.Option[string]{huh.NewOption("nyc1", "nyc1")}, dependentOptions(zones, answers()))
+	assert.Equal(t, "", zones.Default)
+}
@@ -0,0 +1,100 @@
+package survey
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Page describes a screen of the form showing all questions with the same page name
+type Page struct {
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// title returns the title of the page, pages without a title are titled by their name
+func (p Page) title() string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return p.Name
+}
+
+// orderPages returns the questions with the questions of a page moved up to the first question of the page.
+// Pages listed in pages come first in the listed order, followed by all other questions in file order.
+func orderPages(questions []*Question, pages []Page) []*Question {
+	rank := make(map[string]int, len(pages))
+	for i, page := range pages {
+		rank[page.Name] = i
+	}
+	first := make(map[string]int)
+	for i, question := range questions {
+		if _, ok := first[question.Page]; !ok && question.Page != "" {
+			first[question.Page] = i
+		}
+	}
+
+	type position struct{ rank, anchor int }
+	positions := make(map[*Question]position, len(questions))
+	for i, question := range questions {
+		p := position{rank: len(pages), anchor: i}
+		if question.Page != "" {
+			p.anchor = first[question.Page]
+			if r, ok := rank[question.Page]; ok {
+				p.rank = r
+			}
+		}
+		positions[question] = p
+	}
+
+	ordered := append([]*Question(nil), questions...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := positions[ordered[i]], positions[ordered[j]]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		return a.anchor < b.anchor
+	})
+	return ordered
+}
+
+// onPage reports whether q is shown on its page. Groups are the smallest unit huh can hide, so questions
+// which are hidden or shown on their own (conditional, answered or prompted again) get a screen of their own.
+func onPage(q *Question, hidden func() bool) bool {
+	return q.Page != "" && q.When == "" && hidden == nil && !q.answered && q.reprompt == ""
+}
+
+// pageGroup collects the fields of the questions on a page until the group is built
+type pageGroup struct {
+	index   int
+	fields  []huh.Field
+	refresh []func()
+}
+
+// build creates the group of the page, templated defaults are kept in sync like on screens of their own
+func (p *pageGroup) build(page Page) *huh.Group {
+	group := huh.NewGroup(p.fields...).Title(page.title())
+	if page.Description != "" {
+		group.Description(page.Description)
+	}
+	if len(p.refresh) > 0 {
+		group.WithHideFunc(func() bool {
+			for _, refresh := range p.refresh {
+				refresh()
+			}
+			return false
+		})
+	}
+	return group
+}
+
+// findPage returns the page named name, pages which are not described only have their name
+func findPage(pages []Page, name string) Page {
+	for _, page := range pages {
+		if page.Name == name {
+			return page
+		}
+	}
+	return Page{Name: name}
+}
@@ -0,0 +1,140 @@
+package survey
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pagesYAML = `
+vm:
+  questions:
+    - prompt: "Name?"
+      name: "name"
+      kind: "ask"
+      default: "web"
+      page: "general"
+    - prompt: "CPUs?"
+      name: "cpus"
+      kind: "ask"
+      type: "int"
+      default: "2"
+      page: "sizing"
+    - prompt: "Owner?"
+      name: "owner"
+      kind: "ask"
+      default: "team-a"
+      page: "general"
+    - prompt: "Hostname?"
+      name: "hostname"
+      kind: "ask"
+      default: "{{ .name }}-vm"
+      page: "general"
+    - prompt: "Memory in GB?"
+      name: "memory"
+      kind: "ask"
+      type: "int"
+      default: "4"
+      page: "sizing"
+    - prompt: "Notes?"
+      name: "notes"
+      kind: "ask"
+  pages:
+    - name: "sizing"
+      title: "Sizing"
+      description: "CPU and memory of the VM"
+    - name: "general"
+`
+
+func questionNames(questions []*Question) []string {
+	names := make([]string, len(questions))
+	for i, question := range questions {
+		names[i] = question.Name
+	}
+	return names
+}
+
+func TestOrderPages(t *testing.T) {
+	questions := []*Question{
+		{Name: "a", Page: "one"},
+		{Name: "b"},
+		{Name: "c", Page: "two"},
+		{Name: "d", Page: "one"},
+		{Name: "e"},
+	}
+
+	// WITHOUT DESCRIBED PAGES THE QUESTIONS OF A PAGE MOVE UP TO ITS FIRST QUESTION
+	assert.Equal(t, []string{"a", "d", "b", "c", "e"}, questionNames(orderPages(questions, nil)))
+
+	// DESCRIBED PAGES COME FIRST IN THE DESCRIBED ORDER
+	assert.Equal(t, []string{"c", "a", "d", "b", "e"}, questionNames(orderPages(questions, []Page{{Name: "two"}, {Name: "one"}})))
+
+	// UNDESCRIBED PAGES FOLLOW IN FILE ORDER
+	assert.Equal(t, []string{"c", "a", "d", "b", "e"}, questionNames(orderPages(questions, []Page{{Name: "two"}})))
+}
+
+func TestBuildSurveyPages(t *testing.T) {
+	filename := createTempYAMLFile(t, pagesYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	survey, err := LoadSurveyFile(filename, "vm")
+	assert.NoError(t, err)
+	assert.Len(t, survey.Pages, 2)
+
+	groups, _, err := buildGroups(orderPages(survey.Questions, survey.Pages), nil, nil, survey.Pages)
+	assert.NoError(t, err)
+	assert.Len(t, groups, 3)
+
+	form, _, err := BuildSurvey(survey.Questions, survey.Pages...)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+
+	// CONDITIONAL QUESTIONS GET A SCREEN OF THEIR OWN AFTER THEIR PAGE
+	survey.Questions[2].When = "name != ''"
+	groups, _, err = buildGroups(orderPages(survey.Questions, survey.Pages), nil, nil, survey.Pages)
+	assert.NoError(t, err)
+	assert.Len(t, groups, 4)
+}
+
+func TestRunnerPages(t *testing.T) {
+	filename := createTempYAMLFile(t, pagesYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	_, err := LoadQuestionFileStrict(filename, "vm")
+	assert.NoError(t, err)
+
+	answers, err := NewRunner(WithQuestionFile(filename, "vm"), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"name": "web", "cpus": 2, "owner": "team-a", "hostname": "web-vm", "memory": 4, "notes": ""}, answers)
+}
+
+func TestLoadQuestionFileStrictPages(t *testing.T) {
+	filename := createTempYAMLFile(t, `
+vm:
+  questions:
+    - prompt: "CPUs?"
+      name: "cpus"
+      page: "sizing"
+  pages:
+    - name: "sizeing"
+    - title: "General"
+`)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	_, err := LoadQuestionFileStrict(filename, "vm")
+	assert.Equal(t, Diagnostics{
+		{File: filename, Line: 8, Column: 7, Message: `page "sizeing" is not used by any question`},
+		{File: filename, Line: 9, Column: 7, Message: "page has no name"},
+	}, err)
+}
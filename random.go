@@ -3,35 +3,105 @@ package survey
 import (
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-var (
-	allAnswers = make(map[string]interface{})
-)
-
+// GetRandomAnswers answers the questions with random values, dotted names are returned as nested maps
 func GetRandomAnswers(questions []*Question) map[string]interface{} {
+	answers := make(map[string]interface{})
+	randomAnswers(questions, answers)
+	return nestAnswers(answers)
+}
+
+// randomAnswers adds random answers of all visible questions to answers, which also holds the answers visible to them
+func randomAnswers(questions []*Question, answers map[string]interface{}) {
 
 	for _, q := range questions {
 
+		// SKIP QUESTIONS WHOSE CONDITION IS NOT MET BY THE PREVIOUS ANSWERS
+		if !questionVisible(q, answers) {
+			delete(answers, q.Name)
+			continue
+		}
+
+		// COMPUTE DERIVED ANSWERS FROM THE ANSWERS GIVEN SO FAR
+		if q.Kind == "computed" {
+			value, err := computedValue(q, answers)
+			if err != nil {
+				log.Printf("%s COULD NOT BE COMPUTED: %v", q.Name, err)
+				delete(answers, q.Name)
+				continue
+			}
+			answers[q.Name] = value
+			continue
+		}
+
 		r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
+		// REPEAT BLOCKS GET A RANDOM NUMBER OF ENTRIES WITH RANDOM ANSWERS
+		if q.Kind == "repeat" {
+			if !q.answered {
+				maxCount := q.MaxItems
+				if maxCount <= 0 {
+					maxCount = q.MinItems + 3
+				}
+				q.Default = strconv.Itoa(r.Intn(maxCount-q.MinItems+1) + q.MinItems)
+			}
+			items, _ := repeatItems(q, answers, func(children []*Question, scope map[string]interface{}) error {
+				randomAnswers(children, scope)
+				return nil
+			})
+			answers[q.Name] = items
+			continue
+		}
+
+		// KEEP PRESET ANSWERS (E.G. FROM AN ANSWERS FILE)
+		if q.answered {
+			answers[q.Name] = answerValue(q)
+			randomEach(q, answers)
+			continue
+		}
+
+		// LOAD DYNAMIC OPTIONS FROM THE REGISTERED OPTIONS FUNCTION
+		if err := resolveOptions(q, answers); err != nil {
+			log.Printf("OPTIONS OF %s COULD NOT BE LOADED: %v", q.Name, err)
+		}
+
+		// RENDER TEMPLATED DEFAULTS AGAINST THE ANSWERS GIVEN SO FAR
+		if q.Kind != "function" && hasTemplate(q) {
+			if rendered, err := resolveDefault(q, answers); err == nil {
+				q.Default = rendered
+			} else {
+				log.Printf("DEFAULT OF %s COULD NOT BE RENDERED: %v", q.Name, err)
+			}
+		}
+
 		switch q.Kind {
 		case "select":
 			if len(q.Options) > 0 {
-				q.Default = q.Options[rand.Intn(len(q.Options))]
+				q.Default = q.Options[rand.Intn(len(q.Options))].Value
 			}
-		case "ask":
+		case "list":
+			q.Default = strings.Join(randomSelection(q, r), ",")
+		case "confirm":
+			q.Default = strconv.FormatBool(r.Intn(2) == 0)
+		case "ask", "password", "text":
 			if q.Default == "" {
 				q.Default = generateRandomValue(q, r)
 			}
 		case "function":
 			if q.DefaultFunction != "" {
 				if fn, ok := DefaultFunctions[q.DefaultFunction]; ok {
-					q.Default = fn(q.DefaultParams)
+					params, err := renderParams(q.DefaultParams, answers)
+					if err != nil {
+						log.Printf("PARAMS OF %s COULD NOT BE RENDERED: %v", q.Name, err)
+					}
+					q.Default = fn(params)
 				} else {
 					log.Printf("FUNCTION %s NOT FOUND, USING EMPTY STRING", q.DefaultFunction)
 					q.Default = ""
@@ -44,10 +114,46 @@ func GetRandomAnswers(questions []*Question) map[string]interface{} {
 		}
 
 		// CONVERT TO PROPER TYPE
-		allAnswers[q.Name] = ConvertToType(q.Default, q.Type)
+		answers[q.Name] = answerValue(q)
+		randomEach(q, answers)
+	}
+}
+
+// randomEach adds random answers to the follow-up questions of every selected option of a list question
+func randomEach(q *Question, answers map[string]interface{}) {
+	if len(q.Foreach) == 0 {
+		return
 	}
 
-	return allAnswers
+	items, _ := eachItems(q, answers, func(children []*Question, scope map[string]interface{}) error {
+		randomAnswers(children, scope)
+		return nil
+	})
+	answers[eachName(q)] = items
+}
+
+// randomSelection picks a random subset of the option values of a list question, respecting MinItems
+// and MaxItems, the values keep the order of the options
+func randomSelection(q *Question, r *rand.Rand) []string {
+	values := optionValues(q.Options)
+
+	maxItems := len(values)
+	if q.MaxItems > 0 && q.MaxItems < maxItems {
+		maxItems = q.MaxItems
+	}
+	minItems := q.MinItems
+	if minItems > maxItems {
+		minItems = maxItems
+	}
+
+	picked := r.Perm(len(values))[:r.Intn(maxItems-minItems+1)+minItems]
+	sort.Ints(picked)
+
+	selection := make([]string, len(picked))
+	for i, index := range picked {
+		selection[i] = values[index]
+	}
+	return selection
 }
 
 func generateRandomValue(q *Question, r *rand.Rand) string {
@@ -61,20 +167,38 @@ func generateRandomValue(q *Question, r *rand.Rand) string {
 		maxLen = minLen + 10
 	}
 
+	// LIST TYPES GET ONE TO THREE RANDOM ITEMS
+	if elem, ok := strings.CutPrefix(q.Type, "[]"); ok {
+		item := *q
+		item.Type = elem
+		items := make([]string, r.Intn(3)+1)
+		for i := range items {
+			items[i] = generateRandomValue(&item, r)
+		}
+		return strings.Join(items, ",")
+	}
+
 	switch q.Type {
 	case "int":
-		min := 0
-		max := 9999
-		if minLen > 0 {
-			min = pow10(minLen - 1)
+		min, max := randomBounds(q, 0, 9999)
+		lo, hi := int(math.Ceil(min)), int(math.Floor(max))
+		if hi < lo {
+			return strconv.Itoa(lo)
 		}
-		if maxLen > 0 {
-			max = pow10(maxLen) - 1
-			if max < min {
-				max = min * 10
-			}
+		return fmt.Sprintf("%d", r.Intn(hi-lo+1)+lo)
+
+	case "float":
+		min, max := randomBounds(q, 0, 100)
+		f := math.Round((min+r.Float64()*(max-min))*100) / 100
+		return strconv.FormatFloat(math.Max(min, math.Min(max, f)), 'f', -1, 64)
+
+	case "duration":
+		min, max := randomBounds(q, 0, float64(time.Hour))
+		lo, hi := int64(math.Ceil(min/float64(time.Second))), int64(math.Floor(max/float64(time.Second)))
+		if hi < lo {
+			return time.Duration(min).String()
 		}
-		return fmt.Sprintf("%d", r.Intn(max-min+1)+min)
+		return (time.Duration(r.Int63n(hi-lo+1)+lo) * time.Second).String()
 
 	case "boolean":
 		if r.Intn(2) == 0 {
@@ -92,24 +216,34 @@ func generateRandomValue(q *Question, r *rand.Rand) string {
 	}
 }
 
-func ConvertToType(value string, typ string) interface{} {
-	switch typ {
-	case "int":
-		if i, err := strconv.Atoi(value); err == nil {
-			return i
-		}
-		return 0
-	case "boolean":
-		return strings.ToLower(value) == "true" || value == "Yes"
-	default:
-		return value
+// randomBounds returns the min and max bounds of a numeric question, missing bounds are derived
+// from the given default range
+func randomBounds(q *Question, defaultMin, defaultMax float64) (float64, float64) {
+	min, max := defaultMin, defaultMax
+	hasMin, hasMax := false, false
+
+	if value, err := parseValue(q.Min, q.Type); q.Min != "" && err == nil {
+		min, hasMin = numericValue(value), true
+	}
+	if value, err := parseValue(q.Max, q.Type); q.Max != "" && err == nil {
+		max, hasMax = numericValue(value), true
 	}
-}
 
-func pow10(n int) int {
-	if n <= 0 {
-		return 1
+	switch {
+	case hasMin && !hasMax && max < min:
+		max = min + defaultMax - defaultMin
+	case hasMax && !hasMin && min > max:
+		min = max - (defaultMax - defaultMin)
 	}
-	return 10 * pow10(n-1)
+	return min, max
+}
 
+// ConvertToType converts value to typ ("int", "float", "duration", "boolean", "string" or a list type
+// like "[]int"), values which cannot be converted yield the zero value of typ
+func ConvertToType(value string, typ string) interface{} {
+	converted, err := parseValue(value, typ)
+	if err != nil {
+		return zeroValue(typ)
+	}
+	return converted
 }
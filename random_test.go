@@ -1,8 +1,11 @@
 package survey
 
 import (
+	"math/rand"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestConvertToType(t *testing.T) {
@@ -42,6 +45,36 @@ func TestConvertToType(t *testing.T) {
 			typ:   "boolean",
 			want:  true,
 		},
+		{
+			name:  "String to float",
+			value: "2.5",
+			typ:   "float",
+			want:  2.5,
+		},
+		{
+			name:  "String to duration",
+			value: "1h30m",
+			typ:   "duration",
+			want:  90 * time.Minute,
+		},
+		{
+			name:  "Invalid string to duration",
+			value: "soon",
+			typ:   "duration",
+			want:  time.Duration(0),
+		},
+		{
+			name:  "String to int list",
+			value: "80, 443",
+			typ:   "[]int",
+			want:  []int{80, 443},
+		},
+		{
+			name:  "String to string list",
+			value: "a,b",
+			typ:   "[]string",
+			want:  []string{"a", "b"},
+		},
 		{
 			name:  "String remains string",
 			value: "hello",
@@ -59,24 +92,94 @@ func TestConvertToType(t *testing.T) {
 	}
 }
 
-func TestPow10(t *testing.T) {
-	tests := []struct {
-		name string
-		n    int
-		want int
-	}{
-		{"0", 0, 1},
-		{"1", 1, 10},
-		{"2", 2, 100},
-		{"3", 3, 1000},
-		{"negative", -1, 1},
+func TestGetRandomAnswersWhen(t *testing.T) {
+	questions := []*Question{
+		{Name: "manage_filesystem", Kind: "select", Options: NewOptions("false"), Type: "boolean"},
+		{Name: "lvm_var_sizing", Kind: "ask", Default: "20", When: "manage_filesystem == true"},
+		{Name: "hostname", Kind: "ask", Default: "web01", When: "!manage_filesystem"},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := pow10(tt.n); got != tt.want {
-				t.Errorf("pow10() = %v, want %v", got, tt.want)
+	answers := GetRandomAnswers(questions)
+
+	if _, ok := answers["lvm_var_sizing"]; ok {
+		t.Errorf("GetRandomAnswers() returned hidden question lvm_var_sizing")
+	}
+	if got := answers["hostname"]; got != "web01" {
+		t.Errorf("GetRandomAnswers() hostname = %v, want web01", got)
+	}
+}
+
+func TestGetRandomAnswersConfirm(t *testing.T) {
+	answers := GetRandomAnswers([]*Question{{Name: "likes_coffee", Kind: "confirm"}})
+
+	if _, ok := answers["likes_coffee"].(bool); !ok {
+		t.Errorf("GetRandomAnswers() likes_coffee = %#v, want a bool", answers["likes_coffee"])
+	}
+}
+
+func TestGetRandomAnswersFresh(t *testing.T) {
+	GetRandomAnswers([]*Question{{Name: "hostname", Kind: "ask", Default: "web01"}})
+	answers := GetRandomAnswers([]*Question{{Name: "likes_coffee", Kind: "confirm"}})
+
+	if _, ok := answers["hostname"]; ok {
+		t.Errorf("GetRandomAnswers() returned hostname of a previous call")
+	}
+}
+
+func TestGetRandomAnswersList(t *testing.T) {
+	options := NewOptions("cilium", "longhorn", "ingress", "certmanager")
+
+	for i := 0; i < 20; i++ {
+		answers := GetRandomAnswers([]*Question{{Name: "addons", Kind: "list", Options: options, MinItems: 1, MaxItems: 2}})
+
+		addons, ok := answers["addons"].([]string)
+		if !ok {
+			t.Fatalf("GetRandomAnswers() addons = %#v, want a []string", answers["addons"])
+		}
+		if len(addons) < 1 || len(addons) > 2 {
+			t.Errorf("GetRandomAnswers() selected %d addons, want 1 or 2", len(addons))
+		}
+		for _, addon := range addons {
+			if !contains(optionValues(options), addon) {
+				t.Errorf("GetRandomAnswers() selected unknown addon %s", addon)
 			}
-		})
+		}
+	}
+}
+
+func TestGenerateRandomValueBounds(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		n, err := strconv.Atoi(generateRandomValue(&Question{Type: "int", Min: "18", Max: "21"}, r))
+		if err != nil || n < 18 || n > 21 {
+			t.Errorf("generateRandomValue() int = %d, want 18..21", n)
+		}
+
+		f, err := strconv.ParseFloat(generateRandomValue(&Question{Type: "float", Min: "0.5", Max: "1.5"}, r), 64)
+		if err != nil || f < 0.5 || f > 1.5 {
+			t.Errorf("generateRandomValue() float = %v, want 0.5..1.5", f)
+		}
+
+		d, err := time.ParseDuration(generateRandomValue(&Question{Type: "duration", Min: "30s", Max: "2m"}, r))
+		if err != nil || d < 30*time.Second || d > 2*time.Minute {
+			t.Errorf("generateRandomValue() duration = %v, want 30s..2m", d)
+		}
+
+		q := &Question{Type: "[]int", Min: "1", Max: "9"}
+		if err := validateInput(q, generateRandomValue(q, r)); err != nil {
+			t.Errorf("generateRandomValue() []int: %v", err)
+		}
+	}
+}
+
+func TestGetRandomAnswersComputed(t *testing.T) {
+	answers := GetRandomAnswers([]*Question{
+		{Name: "hostname", Kind: "ask", Default: "web01"},
+		{Name: "fqdn", Kind: "computed", Expr: `hostname + ".example.com"`},
+	})
+
+	if got := answers["fqdn"]; got != "web01.example.com" {
+		t.Errorf("GetRandomAnswers() fqdn = %v, want web01.example.com", got)
 	}
 }
@@ -0,0 +1,197 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/huh"
+)
+
+// DefaultMaxRepeat limits the entries of a repeat block without maxItems in the interactive form
+var DefaultMaxRepeat = 10
+
+// repeatCount returns the number of entries of a repeat question, its default or else minItems
+func repeatCount(q *Question) int {
+	if q.Default == "" {
+		return q.MinItems
+	}
+	if n, ok := ConvertToType(q.Default, "int").(int); ok && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// repeatMax returns the number of entries the form provides for a repeat question
+func repeatMax(q *Question) int {
+	if q.MaxItems > 0 {
+		return q.MaxItems
+	}
+	return DefaultMaxRepeat
+}
+
+// validateCount checks the number of entries of a repeat question against minItems and maxItems
+func validateCount(q *Question, count int) error {
+	if count < q.MinItems {
+		return fmt.Errorf("AT LEAST %d ENTRIES REQUIRED", q.MinItems)
+	}
+	if q.MaxItems > 0 && count > q.MaxItems {
+		return fmt.Errorf("AT MOST %d ENTRIES ALLOWED", q.MaxItems)
+	}
+	return nil
+}
+
+// ensureInstances makes sure q holds at least n copies of its child questions, one per entry
+func ensureInstances(q *Question, n int) {
+	for i := len(q.instances); i < n; i++ {
+		children := make([]*Question, len(q.Repeat))
+		for j, child := range q.Repeat {
+			instance := *child
+			instance.Prompt = fmt.Sprintf("%s #%d: %s", q.Name, i+1, child.Prompt)
+			instance.instances = nil
+			children[j] = &instance
+		}
+		q.instances = append(q.instances, children)
+	}
+}
+
+// repeatItems returns the answers of the entries of a repeat question as a list of maps. answer fills
+// in the answers of the child questions of an entry, given a copy of the answers so far as scope.
+func repeatItems(q *Question, answers map[string]interface{}, answer func(children []*Question, scope map[string]interface{}) error) ([]map[string]interface{}, error) {
+	count := repeatCount(q)
+	ensureInstances(q, count)
+
+	items := make([]map[string]interface{}, 0, count)
+	for _, children := range q.instances[:count] {
+		item, err := scopedItem(children, answers, answer)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// scopedItem returns the answers of the child questions of a repeat entry or foreach option, answer
+// fills them in given a copy of the answers so far as scope
+func scopedItem(children []*Question, answers map[string]interface{}, answer func(children []*Question, scope map[string]interface{}) error) (map[string]interface{}, error) {
+	scope := make(map[string]interface{}, len(answers))
+	for name, value := range answers {
+		scope[name] = value
+	}
+	// CHILD ANSWERS SHADOW ANSWERS OF THE SAME NAME OUTSIDE OF THE BLOCK
+	for _, child := range children {
+		delete(scope, child.Name)
+	}
+
+	if err := answer(children, scope); err != nil {
+		return nil, err
+	}
+
+	item := make(map[string]interface{})
+	for _, child := range children {
+		if value, ok := scope[child.Name]; ok {
+			item[child.Name] = value
+		}
+	}
+	return item, nil
+}
+
+// validateScoped validates the answers of the child questions of a repeat entry or foreach option,
+// the question of a *ValidationError is prefixed with the entry, e.g. disks[0].size
+func validateScoped(children []*Question, item map[string]interface{}, prefix string) error {
+	if _, err := validateAnswers(children, item); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			validationErr.Question = prefix + "." + validationErr.Question
+		}
+		return err
+	}
+	return nil
+}
+
+// presetRepeat presets a repeat question from a list of maps (one per entry) or from a number of entries
+func presetRepeat(q *Question, value interface{}) {
+	if _, isCount := toNumber(value); isCount {
+		q.Default = toString(value)
+		q.answered = true
+		return
+	}
+
+	items := toSlice(value)
+	ensureInstances(q, len(items))
+	for i, item := range items {
+		if entry, ok := toStringMap(item); ok {
+			ApplyAnswers(q.instances[i], entry)
+		}
+	}
+
+	q.Default = strconv.Itoa(len(items))
+	q.answered = true
+}
+
+// buildRepeat builds the groups of a repeat question: the number of entries followed by the child questions
+// of every possible entry, entries beyond the entered number are hidden
+func buildRepeat(q *Question, previous func() map[string]interface{}, hidden func() bool) ([]*huh.Group, error) {
+	if q.Default == "" {
+		q.Default = strconv.Itoa(q.MinItems)
+	}
+
+	blockHidden := func() bool {
+		return (hidden != nil && hidden()) || !questionVisible(q, previous())
+	}
+
+	countInput := huh.NewInput().
+		Title(q.Prompt).
+		Value(&q.Default).
+		Validate(func(input string) error {
+			count, err := parseValue(input, "int")
+			if err != nil {
+				return err
+			}
+			if count.(int) > repeatMax(q) {
+				return fmt.Errorf("AT MOST %d ENTRIES ALLOWED", repeatMax(q))
+			}
+			return validateCount(q, count.(int))
+		})
+	describeField(countInput, q)
+
+	countFields := []huh.Field{countInput}
+	if q.Help != "" {
+		countFields = append(countFields, huh.NewNote().Description(q.Help))
+	}
+	countGroup := huh.NewGroup(countFields...)
+
+	switch {
+	case q.reprompt != "":
+		// A VIOLATED RULE PROMPTS THE NUMBER AND ALL ENTRIES AGAIN
+		countGroup.Description(q.reprompt).WithHideFunc(blockHidden)
+	case q.answered:
+		countGroup.WithHide(true)
+	default:
+		countGroup.WithHideFunc(blockHidden)
+	}
+	groups := []*huh.Group{countGroup}
+
+	ensureInstances(q, repeatMax(q))
+	if q.reprompt != "" {
+		for _, children := range q.instances[:repeatMax(q)] {
+			forEachQuestion(children, func(child *Question) {
+				child.reprompt = q.reprompt
+			})
+		}
+	}
+	for i, children := range q.instances[:repeatMax(q)] {
+		index := i
+		entryGroups, _, err := buildGroups(children, previous, func() bool {
+			return blockHidden() || index >= repeatCount(q)
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, entryGroups...)
+	}
+
+	return groups, nil
+}
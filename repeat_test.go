@@ -0,0 +1,177 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var repeatYAML = `
+vm:
+  - prompt: "Datastore?"
+    name: "datastore"
+    kind: "ask"
+    default: "ssd"
+  - prompt: "How many disks?"
+    name: "disks"
+    kind: "repeat"
+    default: "2"
+    minItems: 1
+    maxItems: 4
+    repeat:
+      - prompt: "Size in GB?"
+        name: "size"
+        kind: "ask"
+        type: "int"
+        default: "20"
+        min: 1
+      - prompt: "Mount point?"
+        name: "mount"
+        kind: "ask"
+        default: "/data"
+      - prompt: "Thin provisioned?"
+        name: "thin"
+        kind: "confirm"
+        default: "true"
+        when: "datastore == 'ssd'"
+`
+
+func TestRunnerRepeat(t *testing.T) {
+	filename := createTempYAMLFile(t, repeatYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFileStrict(filename, "vm")
+	assert.NoError(t, err)
+	assert.Len(t, questions[1].Repeat, 3)
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"size": 20, "mount": "/data", "thin": true},
+		{"size": 20, "mount": "/data", "thin": true},
+	}, answers["disks"])
+
+	// ENTRIES ARE PRESET FROM A LIST OF MAPS, MISSING ANSWERS USE THE DEFAULTS
+	questions, err = LoadQuestionFile(filename, "vm")
+	assert.NoError(t, err)
+	answers, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{
+		"datastore": "hdd",
+		"disks": []interface{}{
+			map[string]interface{}{"size": 100, "mount": "/var"},
+			map[string]interface{}{"size": 50},
+			map[string]interface{}{},
+		},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"size": 100, "mount": "/var"},
+		{"size": 50, "mount": "/data"},
+		{"size": 20, "mount": "/data"},
+	}, answers["disks"])
+
+	// EVERY ENTRY IS VALIDATED
+	questions, err = LoadQuestionFile(filename, "vm")
+	assert.NoError(t, err)
+	_, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{
+		"disks": []interface{}{map[string]interface{}{"size": 10}, map[string]interface{}{"size": 0}},
+	}), WithInteractive(false)).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "disks[1].size", validationErr.Question)
+
+	questions, err = LoadQuestionFile(filename, "vm")
+	assert.NoError(t, err)
+	_, err = NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{"disks": 5}), WithInteractive(false)).Run(context.Background())
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "disks", validationErr.Question)
+}
+
+func TestBuildSurveyRepeat(t *testing.T) {
+	filename := createTempYAMLFile(t, repeatYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFile(filename, "vm")
+	assert.NoError(t, err)
+
+	form, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+	assert.Len(t, questions[1].instances, 4)
+	assert.Equal(t, "disks #2: Size in GB?", questions[1].instances[1][0].Prompt)
+
+	// THE ENTRIES OF THE FORM ARE COLLECTED UP TO THE ENTERED COUNT
+	questions[1].Default = "1"
+	questions[1].instances[0][0].Default = "40"
+	assert.Equal(t, []map[string]interface{}{{"size": 40, "mount": "/data", "thin": true}}, collectAnswers(questions)["disks"])
+
+	// A VIOLATED RULE ON THE BLOCK PROMPTS ALL ENTRIES AGAIN
+	questions[1].reprompt = "TOO FEW DISKS"
+	_, _, err = BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.Equal(t, "TOO FEW DISKS", questions[1].instances[3][1].reprompt)
+}
+
+func TestGetRandomAnswersRepeat(t *testing.T) {
+	questions := []*Question{{
+		Name:     "disks",
+		Kind:     "repeat",
+		MinItems: 1,
+		MaxItems: 3,
+		Repeat: []*Question{
+			{Name: "size", Kind: "ask", Type: "int", Min: "10", Max: "100"},
+		},
+	}}
+
+	disks, ok := GetRandomAnswers(questions)["disks"].([]map[string]interface{})
+	if !ok || len(disks) < 1 || len(disks) > 3 {
+		t.Fatalf("GetRandomAnswers() disks = %#v, want 1 to 3 entries", disks)
+	}
+	for _, disk := range disks {
+		if size, ok := disk["size"].(int); !ok || size < 10 || size > 100 {
+			t.Errorf("GetRandomAnswers() size = %v, want 10..100", disk["size"])
+		}
+	}
+}
+
+func TestQuestionsFromStructRepeat(t *testing.T) {
+	type disk struct {
+		Size  int    `survey:"size,min=1"`
+		Mount string `survey:"mount"`
+	}
+	type vm struct {
+		Disks []disk `survey:"disks,min=1,max=4"`
+	}
+
+	questions, err := QuestionsFromStruct(vm{Disks: make([]disk, 2)})
+	assert.NoError(t, err)
+	assert.Equal(t, &Question{
+		Name:     "disks",
+		Prompt:   "Disks",
+		Kind:     "repeat",
+		Default:  "2",
+		MinItems: 1,
+		MaxItems: 4,
+		Repeat: []*Question{
+			{Name: "size", Prompt: "Size", Kind: "ask", Type: "int", Min: "1"},
+			{Name: "mount", Prompt: "Mount", Kind: "ask"},
+		},
+	}, questions[0])
+
+	answers, err := NewRunner(WithQuestions(questions), WithAnswers(map[string]interface{}{
+		"disks": []interface{}{map[string]interface{}{"size": 20, "mount": "/data"}},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+
+	var decoded vm
+	assert.NoError(t, Decode(answers, &decoded))
+	assert.Equal(t, vm{Disks: []disk{{Size: 20, Mount: "/data"}}}, decoded)
+}
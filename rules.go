@@ -0,0 +1,184 @@
+package survey
+
+import "fmt"
+
+// Rule is a survey level check across several answers, e.g. `max_nodes >= min_nodes`.
+// Rules are evaluated after the form completes, the questions of a violated rule are prompted again.
+type Rule struct {
+	Expr    string   `yaml:"expr"`
+	Message string   `yaml:"message,omitempty"`
+	Fields  []string `yaml:"fields,omitempty"` // Questions to prompt again, defaults to the answers used by Expr
+}
+
+func (r Rule) message() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return fmt.Sprintf("RULE %s IS NOT SATISFIED", r.Expr)
+}
+
+// fields returns the names of the questions involved in the rule
+func (r Rule) fields() []string {
+	if len(r.Fields) > 0 {
+		return r.Fields
+	}
+
+	node, err := parseExpression(r.Expr)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	collectIdentifiers(node, &names)
+	return names
+}
+
+// collectIdentifiers appends the answer names referenced by node to names
+func collectIdentifiers(node exprNode, names *[]string) {
+	switch n := node.(type) {
+	case identNode:
+		if !contains(*names, n.name) {
+			*names = append(*names, n.name)
+		}
+	case listNode:
+		for _, item := range n.items {
+			collectIdentifiers(item, names)
+		}
+	case unaryNode:
+		collectIdentifiers(n.operand, names)
+	case binaryNode:
+		collectIdentifiers(n.left, names)
+		collectIdentifiers(n.right, names)
+	}
+}
+
+// checkRuleExpressions reports rules whose expression cannot be parsed
+func checkRuleExpressions(rules []Rule) error {
+	for _, rule := range rules {
+		if _, err := parseExpression(rule.Expr); err != nil {
+			return fmt.Errorf("INVALID RULE %q: %w", rule.Expr, err)
+		}
+	}
+	return nil
+}
+
+// violatedRules returns the rules not satisfied by answers, rules which cannot be evaluated count as violated
+func violatedRules(rules []Rule, answers map[string]interface{}) []Rule {
+	var violated []Rule
+	for _, rule := range rules {
+		if ok, err := EvalCondition(rule.Expr, answers); err != nil || !ok {
+			violated = append(violated, rule)
+		}
+	}
+	return violated
+}
+
+// markReprompt flags the answered questions involved in the violated rules to be prompted again,
+// the rule messages are shown with the question. It reports whether any question was flagged.
+func markReprompt(questions []*Question, violated []Rule, answers map[string]interface{}) bool {
+	flagged := false
+	for _, rule := range violated {
+		for _, question := range promptedQuestions(questions, rule.fields(), answers) {
+			if question.reprompt != "" {
+				question.reprompt += "\n"
+			}
+			question.reprompt += rule.message()
+			flagged = true
+		}
+	}
+	return flagged
+}
+
+// promptedQuestions returns the answered questions named by names. Computed questions cannot be prompted,
+// they are replaced by the questions they are computed from.
+func promptedQuestions(questions []*Question, names []string, answers map[string]interface{}) []*Question {
+	var prompted []*Question
+	seen := make(map[string]bool)
+
+	var add func(names []string)
+	add = func(names []string) {
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			for _, question := range questions {
+				if _, ok := answers[question.Name]; !ok || question.Name != name {
+					continue
+				}
+				if question.Kind == "computed" {
+					add(computedFrom(question))
+					continue
+				}
+				prompted = append(prompted, question)
+			}
+		}
+	}
+	add(names)
+
+	return prompted
+}
+
+// computedFrom returns the answer names used by the expr or the templated default of a computed question
+func computedFrom(q *Question) []string {
+	var names []string
+	if q.Expr != "" {
+		if node, err := parseExpression(q.Expr); err == nil {
+			collectIdentifiers(node, &names)
+		}
+		return names
+	}
+
+	text := q.defaultTemplate
+	if text == "" {
+		text = q.Default
+	}
+	return templateFields(text)
+}
+
+// keepAnswers marks the questions with an answer in answers as answered, so only the questions of violated rules
+// are prompted again. Hidden and computed questions as well as unchanged templated defaults are left to follow
+// the new answers: their condition is checked again and their default is rendered again.
+func keepAnswers(questions []*Question, answers map[string]interface{}) {
+	for _, question := range questions {
+		value, ok := answers[question.Name]
+		if !ok || question.Kind == "computed" {
+			continue
+		}
+		if !question.answered && question.defaultTemplate != "" && question.Default == question.renderedDefault {
+			continue
+		}
+		question.answered = true
+
+		switch question.Kind {
+		case "repeat":
+			items, _ := value.([]map[string]interface{})
+			for i, item := range items {
+				if i < len(question.instances) {
+					keepAnswers(question.instances[i], item)
+				}
+			}
+		case "list":
+			items, _ := answers[eachName(question)].(map[string]interface{})
+			for option, item := range items {
+				if entry, ok := item.(map[string]interface{}); ok {
+					keepAnswers(eachInstance(question, option), entry)
+				}
+			}
+		}
+	}
+}
+
+// forEachQuestion calls fn for all questions, including the entries of repeat blocks
+// and the follow-up questions of list options
+func forEachQuestion(questions []*Question, fn func(q *Question)) {
+	for _, question := range questions {
+		fn(question)
+		for _, children := range question.instances {
+			forEachQuestion(children, fn)
+		}
+		for _, children := range question.each {
+			forEachQuestion(children, fn)
+		}
+	}
+}
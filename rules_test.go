@@ -0,0 +1,161 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rulesYAML = `
+cluster:
+  questions:
+    - prompt: "Minimum nodes?"
+      name: "min_nodes"
+      kind: "ask"
+      type: "int"
+      default: "3"
+    - prompt: "Maximum nodes?"
+      name: "max_nodes"
+      kind: "ask"
+      type: "int"
+      default: "1"
+  rules:
+    - expr: "max_nodes >= min_nodes"
+      message: "MAXIMUM NODES MUST NOT BE LESS THAN MINIMUM NODES"
+`
+
+func TestLoadSurveyFile(t *testing.T) {
+	filename := createTempYAMLFile(t, rulesYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	survey, err := LoadSurveyFile(filename, "cluster")
+	assert.NoError(t, err)
+	assert.Len(t, survey.Questions, 2)
+	assert.Equal(t, []Rule{{Expr: "max_nodes >= min_nodes", Message: "MAXIMUM NODES MUST NOT BE LESS THAN MINIMUM NODES"}}, survey.Rules)
+
+	questions, err := LoadQuestionFile(filename, "cluster")
+	assert.NoError(t, err)
+	assert.Len(t, questions, 2)
+
+	// RULES OF THE FILE ARE CHECKED BY THE RUNNER
+	answers, err := NewRunner(WithQuestionFile(filename, "cluster"), WithInteractive(false)).Run(context.Background())
+	var ruleErr *RuleError
+	assert.True(t, errors.As(err, &ruleErr))
+	assert.Equal(t, "ANSWERS VIOLATE RULES: MAXIMUM NODES MUST NOT BE LESS THAN MINIMUM NODES", err.Error())
+	assert.Equal(t, Answers{"min_nodes": 3, "max_nodes": 1}, answers)
+
+	_, err = NewRunner(
+		WithQuestionFile(filename, "cluster"),
+		WithAnswers(map[string]interface{}{"max_nodes": 5}),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunnerRules(t *testing.T) {
+	questions := []*Question{
+		{Name: "lvm_home", Kind: "ask", Type: "int", Default: "30"},
+		{Name: "lvm_root", Kind: "ask", Type: "int", Default: "40"},
+		{Name: "lvm_var", Kind: "ask", Type: "int", Default: "40"},
+	}
+
+	_, err := NewRunner(
+		WithQuestions(questions),
+		WithRules(Rule{Expr: "lvm_home + lvm_root + lvm_var <= 100"}),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.EqualError(t, err, "ANSWERS VIOLATE RULES: RULE lvm_home + lvm_root + lvm_var <= 100 IS NOT SATISFIED")
+
+	_, err = NewRunner(WithQuestions(questions), WithRules(Rule{Expr: "lvm_home +"}), WithInteractive(false)).Run(context.Background())
+	assert.ErrorContains(t, err, "INVALID RULE")
+}
+
+func TestMarkReprompt(t *testing.T) {
+	questions := []*Question{
+		{Name: "min_nodes", Kind: "ask", Type: "int", Default: "3"},
+		{Name: "max_nodes", Kind: "ask", Type: "int", Default: "1"},
+		{Name: "region", Kind: "ask", Default: "eu"},
+		{Name: "zone", Kind: "ask", Default: "eu-1", When: "false"},
+	}
+	answers := collectAnswers(questions)
+	rules := []Rule{
+		{Expr: "max_nodes >= min_nodes", Message: "TOO FEW NODES", Fields: []string{"max_nodes"}},
+		{Expr: "region == 'us' || zone == 'us-1'"},
+	}
+
+	violated := violatedRules(rules, answers)
+	assert.Len(t, violated, 2)
+	assert.Equal(t, []string{"region", "zone"}, violated[1].fields())
+
+	assert.True(t, markReprompt(questions, violated, answers))
+	assert.Equal(t, "", questions[0].reprompt)
+	assert.Equal(t, "TOO FEW NODES", questions[1].reprompt)
+	assert.Equal(t, "RULE region == 'us' || zone == 'us-1' IS NOT SATISFIED", questions[2].reprompt)
+	assert.Equal(t, "", questions[3].reprompt, "hidden questions are not prompted again")
+
+	form, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+}
+
+func TestMarkRepromptComputed(t *testing.T) {
+	questions := []*Question{
+		{Name: "hostname", Kind: "ask", Default: "localhost"},
+		{Name: "domain", Kind: "ask", Default: "local"},
+		{Name: "fqdn", Kind: "computed", Expr: "hostname + '.' + domain"},
+		{Name: "url", Kind: "computed", Default: "https://{{ .fqdn }}"},
+	}
+	answers := collectAnswers(questions)
+
+	// COMPUTED QUESTIONS ARE NOT PROMPTED, THE QUESTIONS THEY ARE COMPUTED FROM ARE
+	violated := violatedRules([]Rule{{Expr: "url != 'https://localhost.local'", Message: "NO LOCALHOST"}}, answers)
+	assert.True(t, markReprompt(questions, violated, answers))
+	assert.Equal(t, "NO LOCALHOST", questions[0].reprompt)
+	assert.Equal(t, "NO LOCALHOST", questions[1].reprompt)
+	assert.Equal(t, "", questions[2].reprompt)
+	assert.Equal(t, "", questions[3].reprompt)
+
+	// A RULE ON COMPUTED QUESTIONS WITHOUT PROMPTABLE QUESTIONS FLAGS NOTHING
+	computed := []*Question{{Name: "fqdn", Kind: "computed", Expr: "'localhost.local'"}}
+	answers = collectAnswers(computed)
+	violated = violatedRules([]Rule{{Expr: "fqdn != 'localhost.local'"}}, answers)
+	assert.False(t, markReprompt(computed, violated, answers))
+}
+
+func TestKeepAnswers(t *testing.T) {
+	questions := []*Question{
+		{Name: "manage_filesystem", Kind: "confirm", Default: "false"},
+		{Name: "filesystem", Kind: "ask", Default: "xfs", When: "manage_filesystem"},
+		{Name: "name", Kind: "ask", Default: "web"},
+		{Name: "hostname", Kind: "ask", Default: "{{ .name }}-vm"},
+		{Name: "alias", Kind: "ask", Default: "{{ .name }}-alias"},
+		{Name: "fqdn", Kind: "computed", Expr: "hostname + '.example.com'"},
+	}
+	_, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	questions[4].Default = "db"
+
+	keepAnswers(questions, collectAnswers(questions))
+	assert.True(t, questions[0].answered)
+	assert.False(t, questions[1].answered, "hidden questions are checked again")
+	assert.True(t, questions[2].answered)
+	assert.False(t, questions[3].answered, "unchanged templated defaults are rendered again")
+	assert.True(t, questions[4].answered, "changed templated defaults are kept")
+	assert.False(t, questions[5].answered, "computed questions are computed again")
+
+	// A NEWLY VISIBLE QUESTION AND RENDERED DEFAULTS FOLLOW THE RE-PROMPTED ANSWERS
+	questions[0].Default = "true"
+	questions[2].Default = "db"
+	_, _, err = BuildSurvey(questions)
+	assert.NoError(t, err)
+	answers := collectAnswers(questions)
+	assert.Equal(t, "xfs", answers["filesystem"])
+	assert.Equal(t, "db-vm", answers["hostname"])
+	assert.Equal(t, "db-vm.example.com", answers["fqdn"])
+}
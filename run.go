@@ -1,38 +1,52 @@
 package survey
 
 import (
+	"context"
+	"errors"
+
 	log "github.com/sirupsen/logrus"
 )
 
 func RunSurvey(profilePath, surveyKey string) (surveyValues map[string]interface{}) {
-	surveyValues = make(map[string]interface{})
-
 	// READ PROFILE AND SURVEY BY KEY
-	survey, _ := LoadQuestionFile(profilePath, surveyKey)
+	survey, _ := LoadSurveyFile(profilePath, surveyKey)
 
-	// IF SURVEY EXISTS, RUN IT
-	if len(survey) > 0 {
-		surveyQuestions, _, err := BuildSurvey(survey)
+	if survey == nil || len(survey.Questions) == 0 {
+		log.Info("NO SURVEY FOUND")
+		return make(map[string]interface{})
+	}
 
-		if err != nil {
-			log.Fatalf("ERROR BUILDING SURVEY: %v", err)
-		}
-		log.Info("SURVEY FOUND")
+	log.Info("SURVEY FOUND")
 
-		// RUN THE INTERACTIVE SURVEY
-		err = surveyQuestions.Run()
-		if err != nil {
-			log.Fatalf("ERROR RUNNING SURVEY: %v", err)
-		}
+	return runLegacy(NewRunner(WithSurvey(survey)))
+}
 
-		// SET ANWERS TO ALL VALUES
-		for _, question := range survey {
-			surveyValues[question.Name] = question.Default
-		}
+// RunSurveyWithAnswersFile runs the survey but only prompts the questions not answered in answersFile
+func RunSurveyWithAnswersFile(profilePath, surveyKey, answersFile string) map[string]interface{} {
+	// READ PROFILE AND SURVEY BY KEY
+	survey, _ := LoadSurveyFile(profilePath, surveyKey)
 
-	} else {
+	if survey == nil || len(survey.Questions) == 0 {
 		log.Info("NO SURVEY FOUND")
+		return make(map[string]interface{})
+	}
+	log.Info("SURVEY FOUND")
+
+	return runLegacy(NewRunner(WithSurvey(survey), WithAnswersFile(answersFile)))
+}
+
+// runLegacy runs the runner the way the original functions did, exiting the process on errors
+func runLegacy(runner *Runner) map[string]interface{} {
+	answers, err := runner.Run(context.Background())
+
+	var validationErr *ValidationError
+	var ruleErr *RuleError
+	switch {
+	case errors.As(err, &validationErr), errors.As(err, &ruleErr):
+		log.Warn(err)
+	case err != nil:
+		log.Fatal(err)
 	}
 
-	return surveyValues
+	return answers
 }
@@ -0,0 +1,438 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Answers holds the answers of a survey by question name
+type Answers map[string]interface{}
+
+// Runner runs a survey and reports errors to the caller instead of exiting the process
+type Runner struct {
+	survey        Survey
+	questions     []*Question
+	rules         []Rule
+	pages         []Page
+	profilePath   string
+	surveyKey     string
+	answers       map[string]interface{}
+	answersFile   string
+	envPrefix     string
+	interactive   bool
+	randomSelects bool
+	flatAnswers   bool
+}
+
+// RunnerOption configures a Runner
+type RunnerOption func(*Runner)
+
+// WithQuestions sets the questions to ask
+func WithQuestions(questions []*Question) RunnerOption {
+	return func(r *Runner) {
+		r.questions = questions
+	}
+}
+
+// WithSurvey sets the questions, rules and pages of survey, its intro and outro are shown around the form
+func WithSurvey(survey *Survey) RunnerOption {
+	return func(r *Runner) {
+		r.survey = *survey
+		r.questions = survey.Questions
+		r.rules = append(r.rules, survey.Rules...)
+		r.pages = append(r.pages, survey.Pages...)
+	}
+}
+
+// WithRules adds survey level rules checked after all questions are answered, the questions of
+// violated rules are prompted again (or a *RuleError is returned when the runner is not interactive)
+func WithRules(rules ...Rule) RunnerOption {
+	return func(r *Runner) {
+		r.rules = append(r.rules, rules...)
+	}
+}
+
+// WithPages orders and describes the pages of the form, questions of a page are shown together
+func WithPages(pages ...Page) RunnerOption {
+	return func(r *Runner) {
+		r.pages = append(r.pages, pages...)
+	}
+}
+
+// WithQuestionFile loads the questions (rules and pages) stored under surveyKey in profilePath when the runner is started
+func WithQuestionFile(profilePath, surveyKey string) RunnerOption {
+	return func(r *Runner) {
+		r.profilePath = profilePath
+		r.surveyKey = surveyKey
+	}
+}
+
+// WithAnswers presets answers by question name, these questions are not prompted
+func WithAnswers(answers map[string]interface{}) RunnerOption {
+	return func(r *Runner) {
+		r.answers = answers
+	}
+}
+
+// WithAnswersFile presets the answers read from a YAML or JSON file, these questions are not prompted
+func WithAnswersFile(filename string) RunnerOption {
+	return func(r *Runner) {
+		r.answersFile = filename
+	}
+}
+
+// WithEnvPrefix presets answers from environment variables named prefix + upper cased question name
+// (e.g. SURVEY_USERNAME), questions with an env field are always looked up by that variable
+func WithEnvPrefix(prefix string) RunnerOption {
+	return func(r *Runner) {
+		r.envPrefix = prefix
+	}
+}
+
+// WithInteractive shows the form if true (default) and questions are left to prompt, otherwise the defaults are used as answers
+func WithInteractive(interactive bool) RunnerOption {
+	return func(r *Runner) {
+		r.interactive = interactive
+	}
+}
+
+// WithRandomSelects answers select questions with a random option and list questions with a random
+// subset of the options when the runner is not interactive
+func WithRandomSelects(randomSelects bool) RunnerOption {
+	return func(r *Runner) {
+		r.randomSelects = randomSelects
+	}
+}
+
+// WithFlatAnswers keeps dotted question names like vm.network.vlan as flat keys in the answers,
+// by default they are returned as nested maps
+func WithFlatAnswers(flat bool) RunnerOption {
+	return func(r *Runner) {
+		r.flatAnswers = flat
+	}
+}
+
+// NewRunner creates a Runner configured by opts
+func NewRunner(opts ...RunnerOption) *Runner {
+	r := &Runner{
+		interactive: true,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run asks the questions and returns the answers of all visible questions, dotted names are nested
+// unless WithFlatAnswers is set.
+// Errors are one of *KeyNotFoundError, *FunctionNotFoundError, *ValidationError, *RuleError or ErrUserAborted
+// (possibly wrapped), a *ValidationError or *RuleError is returned together with the answers.
+func (r *Runner) Run(ctx context.Context) (Answers, error) {
+	answers, err := r.run(ctx)
+	if answers == nil || r.flatAnswers {
+		return answers, err
+	}
+	return nestAnswers(answers), err
+}
+
+func (r *Runner) run(ctx context.Context) (Answers, error) {
+	survey := r.survey
+	questions := r.questions
+	rules := r.rules
+	pages := r.pages
+
+	// READ PROFILE AND SURVEY BY KEY
+	if r.profilePath != "" {
+		loaded, err := LoadSurveyFile(r.profilePath, r.surveyKey)
+		if err != nil {
+			return nil, err
+		}
+		survey = *loaded
+		questions = survey.Questions
+		rules = append(survey.Rules, rules...)
+		pages = append(survey.Pages, pages...)
+	}
+
+	// ORDER THE QUESTIONS LIKE THE PAGES OF THE FORM, ANSWERS DEPEND ON THE QUESTIONS SHOWN BEFORE
+	questions = orderPages(questions, pages)
+
+	if err := checkRuleExpressions(rules); err != nil {
+		return nil, fmt.Errorf("ERROR BUILDING SURVEY: %w", err)
+	}
+
+	// PRESET ANSWERS FROM THE ANSWERS FILE, THE ENVIRONMENT AND CODE (IN INCREASING PRECEDENCE)
+	if r.answersFile != "" {
+		fileAnswers, err := LoadAnswersFile(r.answersFile)
+		if err != nil {
+			return nil, err
+		}
+		ApplyAnswers(questions, fileAnswers)
+	}
+	ApplyEnvOverrides(questions, r.envPrefix)
+	ApplyAnswers(questions, r.answers)
+
+	if !r.interactive {
+		answers, err := r.answerDefaults(questions)
+		if err != nil {
+			return answers, err
+		}
+		if violated := violatedRules(rules, answers); len(violated) > 0 {
+			return answers, &RuleError{Rules: violated}
+		}
+		return answers, nil
+	}
+
+	survey.Questions = questions
+	survey.Pages = pages
+	for {
+		// THE FORM IS ONLY SHOWN IF QUESTIONS ARE LEFT TO PROMPT, A COMPLETE ANSWERS FILE NEEDS NO TERMINAL
+		if pendingQuestions(questions, make(map[string]interface{})) {
+			form, _, err := BuildSurveyForm(&survey)
+			if err != nil {
+				return nil, fmt.Errorf("ERROR BUILDING SURVEY: %w", err)
+			}
+
+			// RUN THE INTERACTIVE SURVEY, THE INTRO IS ONLY SHOWN BEFORE THE FIRST RUN
+			if err := form.RunWithContext(ctx); err != nil {
+				return nil, fmt.Errorf("ERROR RUNNING SURVEY: %w", err)
+			}
+			survey.Intro = ""
+		}
+		forEachQuestion(questions, func(q *Question) {
+			q.reprompt = ""
+		})
+
+		answers, err := validateAnswers(questions, collectAnswers(questions))
+		if err != nil {
+			return answers, err
+		}
+
+		violated := violatedRules(rules, answers)
+		if len(violated) == 0 {
+			return answers, nil
+		}
+
+		// KEEP THE GIVEN ANSWERS AND ONLY PROMPT THE QUESTIONS OF THE VIOLATED RULES AGAIN
+		keepAnswers(questions, answers)
+		if !markReprompt(questions, violated, answers) {
+			return answers, &RuleError{Rules: violated}
+		}
+	}
+}
+
+// pendingQuestions reports whether a visible question is not answered yet or has to be prompted again,
+// answers holds the answers visible to them
+func pendingQuestions(questions []*Question, answers map[string]interface{}) bool {
+	pending := false
+	check := func(children []*Question, scope map[string]interface{}) error {
+		pending = pending || pendingQuestions(children, scope)
+		return nil
+	}
+
+	for _, question := range questions {
+		if !questionVisible(question, answers) {
+			continue
+		}
+		if question.Kind == "computed" {
+			collectInto([]*Question{question}, answers)
+			continue
+		}
+		if !question.answered || question.reprompt != "" {
+			return true
+		}
+
+		if question.Kind == "repeat" {
+			items, _ := repeatItems(question, answers, check)
+			answers[question.Name] = items
+		} else {
+			answers[question.Name] = answerValue(question)
+			if len(question.Foreach) > 0 {
+				items, _ := eachItems(question, answers, check)
+				answers[eachName(question)] = items
+			}
+		}
+		if pending {
+			return true
+		}
+	}
+
+	return false
+}
+
+// answerDefaults answers all visible questions with their (rendered) defaults without showing a form
+func (r *Runner) answerDefaults(questions []*Question) (Answers, error) {
+	answers := make(map[string]interface{})
+	if err := r.defaultAnswers(questions, answers); err != nil {
+		return nil, err
+	}
+
+	return validateAnswers(questions, answers)
+}
+
+// defaultAnswers adds the default answers of all visible questions to answers, which also holds the answers visible to them
+func (r *Runner) defaultAnswers(questions []*Question, answers map[string]interface{}) error {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	for _, question := range questions {
+		if !questionVisible(question, answers) {
+			continue
+		}
+
+		if question.Kind == "computed" {
+			value, err := computedValue(question, answers)
+			if err != nil {
+				return fmt.Errorf("COULD NOT COMPUTE %s: %w", question.Name, err)
+			}
+			answers[question.Name] = value
+			continue
+		}
+
+		if question.Kind == "repeat" {
+			items, err := repeatItems(question, answers, r.defaultAnswers)
+			if err != nil {
+				return err
+			}
+			answers[question.Name] = items
+			continue
+		}
+
+		if question.answered {
+			answers[question.Name] = answerValue(question)
+			if err := r.defaultEach(question, answers); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if question.Kind == "function" || hasTemplate(question) {
+			defaultValue, err := resolveDefault(question, answers)
+			if err != nil {
+				return fmt.Errorf("ERROR BUILDING SURVEY: %w", err)
+			}
+			question.Default = defaultValue
+		}
+
+		if err := resolveOptions(question, answers); err != nil {
+			return fmt.Errorf("ERROR BUILDING SURVEY: %w", err)
+		}
+
+		if r.randomSelects && len(question.Options) > 0 {
+			switch question.Kind {
+			case "select":
+				question.Default = question.Options[rnd.Intn(len(question.Options))].Value
+			case "list":
+				question.Default = strings.Join(randomSelection(question, rnd), ",")
+			}
+		}
+
+		answers[question.Name] = answerValue(question)
+		if err := r.defaultEach(question, answers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// defaultEach answers the follow-up questions of every selected option of a list question
+func (r *Runner) defaultEach(question *Question, answers map[string]interface{}) error {
+	if len(question.Foreach) == 0 {
+		return nil
+	}
+
+	items, err := eachItems(question, answers, r.defaultAnswers)
+	if err != nil {
+		return err
+	}
+	answers[eachName(question)] = items
+	return nil
+}
+
+// validateAnswers checks the answers of all visible input questions, the answers are returned even if one is invalid
+func validateAnswers(questions []*Question, answers map[string]interface{}) (Answers, error) {
+	for _, question := range questions {
+		value, ok := answers[question.Name]
+		if !ok {
+			continue
+		}
+
+		// PRESET ANSWERS OF SELECTS MUST BE ONE OF THE OPTIONS, OPTIONS_BY OPTIONS DEPEND ON THE OTHER ANSWERS
+		values := optionValues(question.Options)
+		restricted := len(values) > 0
+		if question.OptionsBy != nil {
+			values = optionValues(question.OptionsBy.options(answers))
+			restricted = true
+		}
+		if question.answered && question.Kind == "select" && restricted && !contains(values, value) {
+			return answers, &ValidationError{Question: question.Name, Value: value, Err: fmt.Errorf("NOT ONE OF %v", values)}
+		}
+
+		// PRESET ANSWERS OF SELECTS, LISTS AND CONFIRMS MUST BE VALUES OF THE TYPE, INPUTS ARE VALIDATED BELOW
+		if question.answered && (question.Kind == "select" || question.Kind == "list" || question.Kind == "confirm") {
+			typ := question.Type
+			switch question.Kind {
+			case "list":
+				typ = "[]" + strings.TrimPrefix(typ, "[]")
+			case "confirm":
+				typ = "boolean"
+			}
+			if _, err := parseValue(question.Default, typ); err != nil {
+				return answers, &ValidationError{Question: question.Name, Value: question.Default, Err: err}
+			}
+		}
+
+		// EVERY ENTRY OF A REPEAT BLOCK IS VALIDATED LIKE A SURVEY OF ITS OWN
+		if question.Kind == "repeat" {
+			items, _ := value.([]map[string]interface{})
+			if err := validateCount(question, len(items)); err != nil {
+				return answers, &ValidationError{Question: question.Name, Value: len(items), Err: err}
+			}
+			for i, item := range items {
+				if err := validateScoped(question.instances[i], item, fmt.Sprintf("%s[%d]", question.Name, i)); err != nil {
+					return answers, err
+				}
+			}
+			continue
+		}
+
+		if question.Kind == "list" {
+			selected := listItems(question.Default)
+			for _, item := range selected {
+				if question.answered && restricted && !contains(values, item) {
+					return answers, &ValidationError{Question: question.Name, Value: value, Err: fmt.Errorf("%s IS NOT ONE OF %v", item, values)}
+				}
+			}
+			if err := validateSelection(question, selected); err != nil {
+				return answers, &ValidationError{Question: question.Name, Value: value, Err: err}
+			}
+
+			// THE FOLLOW-UP ANSWERS OF EVERY SELECTED OPTION ARE VALIDATED LIKE A SURVEY OF THEIR OWN
+			items, _ := answers[eachName(question)].(map[string]interface{})
+			for _, item := range selected {
+				answer, ok := items[item].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if err := validateScoped(eachInstance(question, item), answer, fmt.Sprintf("%s[%s]", eachName(question), item)); err != nil {
+					return answers, err
+				}
+			}
+			continue
+		}
+
+		if !isInput(question) {
+			continue
+		}
+
+		if err := validateInput(question, question.Default); err != nil {
+			return answers, &ValidationError{Question: question.Name, Value: value, Err: err}
+		}
+	}
+
+	return answers, nil
+}
@@ -0,0 +1,167 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerNonInteractive(t *testing.T) {
+	questions := []*Question{
+		{Name: "username", Kind: "ask", Default: "patrick", MinLength: 2},
+		{Name: "hostname", Kind: "ask", Default: "{{ .username }}-vm"},
+		{Name: "color", Kind: "select", Options: NewOptions("Red", "Blue"), Default: "Blue"},
+		{Name: "lvm", Kind: "ask", Default: "20", When: "color == 'Red'"},
+	}
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"username": "patrick", "hostname": "patrick-vm", "color": "Blue"}, answers)
+}
+
+func TestRunnerAllAnswered(t *testing.T) {
+	questions := []*Question{
+		{Name: "username", Kind: "ask", Default: "patrick"},
+		{Name: "color", Kind: "select", Options: NewOptions("Red", "Blue")},
+		{Name: "lvm", Kind: "ask", When: "color == 'Red'"},
+		{Name: "disks", Kind: "repeat", Repeat: []*Question{{Name: "size", Kind: "ask", Type: "int"}}},
+		{Name: "hostname", Kind: "computed", Default: "{{ .username }}-vm"},
+	}
+	preset := map[string]interface{}{
+		"username": "admin",
+		"color":    "Blue",
+		"disks":    []interface{}{map[string]interface{}{"size": 20}},
+	}
+
+	// THE FORM IS NOT SHOWN (AND NEEDS NO TERMINAL) IF ALL VISIBLE QUESTIONS ARE ANSWERED
+	answers, err := NewRunner(WithQuestions(questions), WithAnswers(preset)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, pendingQuestions(questions, make(map[string]interface{})))
+	assert.Equal(t, Answers{
+		"username": "admin",
+		"color":    "Blue",
+		"disks":    []map[string]interface{}{{"size": 20}},
+		"hostname": "admin-vm",
+	}, answers)
+
+	// A VISIBLE QUESTION WITHOUT ANSWER IS PROMPTED
+	questions = []*Question{
+		{Name: "color", Kind: "select", Options: NewOptions("Red", "Blue")},
+		{Name: "lvm", Kind: "ask", When: "color == 'Red'"},
+	}
+	ApplyAnswers(questions, map[string]interface{}{"color": "Red"})
+	assert.True(t, pendingQuestions(questions, make(map[string]interface{})))
+}
+
+func TestRunnerErrors(t *testing.T) {
+	filename := createTempYAMLFile(t, sampleYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	_, err := NewRunner(WithQuestionFile(filename, "missing"), WithInteractive(false)).Run(context.Background())
+	var keyErr *KeyNotFoundError
+	assert.True(t, errors.As(err, &keyErr))
+	assert.Equal(t, "missing", keyErr.Key)
+
+	_, err = NewRunner(WithQuestions([]*Question{
+		{Name: "drink", Kind: "function", DefaultFunction: "notRegistered"},
+	}), WithInteractive(false)).Run(context.Background())
+	var fnErr *FunctionNotFoundError
+	assert.True(t, errors.As(err, &fnErr))
+	assert.Equal(t, "notRegistered", fnErr.Name)
+
+	answers, err := NewRunner(WithQuestions([]*Question{
+		{Name: "username", Kind: "ask", Default: "p", MinLength: 2},
+	}), WithInteractive(false)).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "username", validationErr.Question)
+	assert.Equal(t, "p", answers["username"])
+}
+
+func TestRunnerConfirm(t *testing.T) {
+	questions := []*Question{
+		{Name: "likes_coffee", Kind: "confirm", Default: "Yes"},
+		{Name: "likes_tea", Kind: "confirm"},
+		{Name: "coffee_style", Kind: "ask", Default: "black", When: "likes_coffee"},
+	}
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{"likes_coffee": true, "likes_tea": false, "coffee_style": "black"}, answers)
+}
+
+func TestRunnerList(t *testing.T) {
+	questions := []*Question{
+		{Name: "addons", Kind: "list", Options: NewOptions("cilium", "longhorn", "ingress"), Default: "cilium,ingress"},
+		{Name: "ports", Kind: "list", Type: "int", Options: NewOptions("80", "443"), Default: "443"},
+		{Name: "storage", Kind: "list", Options: NewOptions("nfs", "ceph")},
+		{Name: "ingress_class", Kind: "ask", Default: "nginx", When: `"ingress" in addons`},
+	}
+
+	answers, err := NewRunner(
+		WithQuestions(questions),
+		WithAnswers(map[string]interface{}{"storage": []interface{}{"ceph", "nfs"}}),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{
+		"addons":        []string{"cilium", "ingress"},
+		"ports":         []int{443},
+		"storage":       []string{"ceph", "nfs"},
+		"ingress_class": "nginx",
+	}, answers)
+
+	_, err = NewRunner(WithQuestions([]*Question{
+		{Name: "addons", Kind: "list", Options: NewOptions("cilium", "longhorn"), MinItems: 1},
+	}), WithInteractive(false)).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+
+	_, err = NewRunner(WithQuestions([]*Question{
+		{Name: "addons", Kind: "list", Options: NewOptions("cilium", "longhorn")},
+	}), WithAnswers(map[string]interface{}{"addons": []string{"istio"}}), WithInteractive(false)).Run(context.Background())
+	assert.True(t, errors.As(err, &validationErr))
+}
+
+func TestRunnerComputed(t *testing.T) {
+	questions := []*Question{
+		{Name: "hostname", Kind: "ask", Default: "web01"},
+		{Name: "domain", Kind: "ask", Default: "example.com"},
+		{Name: "fqdn", Kind: "computed", Expr: `hostname + "." + domain`},
+		{Name: "url", Kind: "computed", Default: "https://{{ .fqdn }}:8443"},
+		{Name: "lvm_home", Kind: "ask", Type: "int", Default: "30"},
+		{Name: "lvm_free", Kind: "computed", Expr: "100 - lvm_home", Type: "string"},
+		{Name: "lvm_var", Kind: "computed", Expr: "lvm_free", When: "lvm_home > 50"},
+	}
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Answers{
+		"hostname": "web01",
+		"domain":   "example.com",
+		"fqdn":     "web01.example.com",
+		"url":      "https://web01.example.com:8443",
+		"lvm_home": 30,
+		"lvm_free": "70",
+	}, answers)
+
+	// COMPUTED QUESTIONS ARE NOT PART OF THE FORM
+	form, _, err := BuildSurvey(questions)
+	assert.NoError(t, err)
+	assert.NotNil(t, form)
+	assert.Equal(t, "web01.example.com", collectAnswers(questions)["fqdn"])
+
+	_, _, err = BuildSurvey([]*Question{{Name: "fqdn", Kind: "computed", Expr: "hostname +"}})
+	assert.Error(t, err)
+
+	_, err = NewRunner(WithQuestions([]*Question{
+		{Name: "ratio", Kind: "computed", Expr: "1 / 0"},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.ErrorContains(t, err, "COULD NOT COMPUTE ratio")
+}
@@ -0,0 +1,42 @@
+package survey
+
+import (
+	"encoding/json"
+)
+
+// Redacted replaces secret answers whenever they are printed, logged or exported
+const Redacted = "********"
+
+// Secret is the answer of a password (or secret) question, it is redacted when formatted
+// with fmt, logged or marshaled to JSON or YAML. Use Reveal to get the plain value.
+type Secret string
+
+// Reveal returns the plain secret
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	return Redacted
+}
+
+func (s Secret) GoString() string {
+	return `"` + Redacted + `"`
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+func (s Secret) MarshalYAML() (interface{}, error) {
+	return Redacted, nil
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+// isSecret reports whether the answer of q must be masked and redacted
+func isSecret(q *Question) bool {
+	return q.Kind == "password" || q.Secret
+}
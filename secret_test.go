@@ -0,0 +1,82 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretIsRedacted(t *testing.T) {
+	answers := map[string]interface{}{
+		"username": "patrick",
+		"password": Secret("hunter2"),
+	}
+
+	assert.Equal(t, "hunter2", answers["password"].(Secret).Reveal())
+	assert.NotContains(t, fmt.Sprintf("%v %s %q %#v", answers, answers["password"], answers["password"], answers), "hunter2")
+
+	for _, format := range []string{"yaml", "json"} {
+		exported, err := ExportAnswers(answers, format)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(exported), "hunter2")
+		assert.Contains(t, string(exported), Redacted)
+		assert.Contains(t, string(exported), "patrick")
+	}
+
+	_, err := ExportAnswers(answers, "toml")
+	assert.Error(t, err)
+}
+
+func TestRunnerPassword(t *testing.T) {
+	questions := []*Question{
+		{Name: "password", Kind: "password", MinLength: 4},
+		{Name: "token", Kind: "ask", Secret: true},
+	}
+
+	answers, err := NewRunner(
+		WithQuestions(questions),
+		WithAnswers(map[string]interface{}{"password": "hunter2", "token": "abc"}),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Secret("hunter2"), answers["password"])
+	assert.Equal(t, Secret("abc"), answers["token"])
+
+	// SECRETS DECODE INTO PLAIN STRINGS
+	var cfg struct {
+		Password string `survey:"password"`
+		Token    Secret `survey:"token"`
+	}
+	assert.NoError(t, Decode(answers, &cfg))
+	assert.Equal(t, "hunter2", cfg.Password)
+	assert.Equal(t, Secret("abc"), cfg.Token)
+
+	// VALIDATION ERRORS DO NOT LEAK THE SECRET
+	_, err = NewRunner(
+		WithQuestions([]*Question{{Name: "password", Kind: "password", MinLength: 10}}),
+		WithAnswers(map[string]interface{}{"password": "hunter2"}),
+		WithInteractive(false),
+	).Run(context.Background())
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+}
+
+func TestSecretInTemplatesAndConditions(t *testing.T) {
+	answers, err := NewRunner(WithQuestions([]*Question{
+		{Name: "pw", Kind: "password"},
+		{Name: "dsn", Kind: "ask", Default: "user:{{ .pw }}@db"},
+		{Name: "url", Kind: "computed", Default: "postgres://user:{{ .pw }}@db", Secret: true},
+		{Name: "rotate", Kind: "ask", Default: "yes", When: "pw"},
+	}), WithAnswers(map[string]interface{}{"pw": "hunter2"}), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+
+	// TEMPLATES RENDER THE PLAIN SECRET
+	assert.Equal(t, "user:hunter2@db", answers["dsn"])
+	assert.Equal(t, "postgres://user:hunter2@db", toString(answers["url"]))
+
+	// AN EMPTY SECRET IS FALSE IN CONDITIONS
+	assert.True(t, truthy(Secret("hunter2")))
+	assert.False(t, truthy(Secret("")))
+}
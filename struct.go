@@ -0,0 +1,208 @@
+package survey
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// QuestionsFromStruct builds questions from the `survey` tags of a struct (or pointer to a struct), e.g.
+//
+//	Username string `survey:"username,prompt='What is your name?',min=2,max=30"`
+//	Color    string `survey:"color,prompt=Favorite color?,options=Red|Blue|Green"`
+//
+// Supported tag options are prompt, kind, type, default, options (separated by |), min, max
+// (value bounds for numbers, number of selected options or entries for slices, length limits otherwise),
+// validate (separated by |), when, env, secret and optional. Kind and type are derived from the field
+// type if not given and non-zero field values become the defaults. Nested structs are prefixed with
+// their name and a dot, slices of structs become repeat blocks. The answers can be read back into the
+// struct with Decode.
+func QuestionsFromStruct(v interface{}) ([]*Question, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("QUESTIONS CAN ONLY BE GENERATED FROM A STRUCT, GOT %T", v)
+	}
+
+	return questionsFromStruct(rv, "")
+}
+
+func questionsFromStruct(rv reflect.Value, prefix string) ([]*Question, error) {
+	var questions []*Question
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("survey")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		name = prefix + name
+
+		fieldValue := rv.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			nested, err := questionsFromStruct(fieldValue, name+".")
+			if err != nil {
+				return nil, err
+			}
+			questions = append(questions, nested...)
+			continue
+		}
+
+		question, err := questionFromField(name, field, fieldValue, opts)
+		if err != nil {
+			return nil, fmt.Errorf("FIELD %s: %w", field.Name, err)
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+// questionFromField builds the question of a single tagged field
+func questionFromField(name string, field reflect.StructField, value reflect.Value, opts map[string]string) (*Question, error) {
+	question := &Question{
+		Name:   name,
+		Prompt: field.Name,
+	}
+
+	// DERIVE KIND, TYPE AND DEFAULT FROM THE GO TYPE
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+		value = reflect.Indirect(value)
+	}
+
+	switch {
+	case fieldType == reflect.TypeOf(Secret("")):
+		question.Kind = "password"
+	case fieldType.Kind() == reflect.Bool:
+		question.Kind = "confirm"
+		question.Type = "boolean"
+	case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct && fieldType.Elem() != durationType:
+		children, err := questionsFromStruct(reflect.New(fieldType.Elem()).Elem(), "")
+		if err != nil {
+			return nil, err
+		}
+		question.Kind = "repeat"
+		question.Repeat = children
+	case fieldType.Kind() == reflect.Slice:
+		question.Kind = "list"
+		question.Type = fieldTypeName(fieldType.Elem())
+	default:
+		question.Kind = "ask"
+		question.Type = fieldTypeName(fieldType)
+	}
+
+	if value.IsValid() && !value.IsZero() {
+		if question.Kind == "repeat" {
+			question.Default = strconv.Itoa(value.Len())
+		} else if fieldType.Kind() == reflect.Slice {
+			items := make([]string, value.Len())
+			for i := range items {
+				items[i] = toString(value.Index(i).Interface())
+			}
+			question.Default = strings.Join(items, ",")
+		} else {
+			question.Default = toString(value.Interface())
+		}
+	}
+
+	// TAG OPTIONS OVERRIDE THE DERIVED VALUES, KIND AND TYPE FIRST SINCE MIN AND MAX DEPEND ON THE TYPE
+	if kind, ok := opts["kind"]; ok {
+		question.Kind = kind
+	}
+	if typ, ok := opts["type"]; ok {
+		question.Type = typ
+	}
+	for key, option := range opts {
+		switch key {
+		case "prompt":
+			question.Prompt = option
+		case "kind", "type":
+		case "default":
+			question.Default = option
+		case "options":
+			question.Options = NewOptions(strings.Split(option, "|")...)
+			if _, explicit := opts["kind"]; !explicit && question.Kind == "ask" {
+				question.Kind = "select"
+			}
+		case "min", "max":
+			// NUMBERS ARE BOUNDED BY VALUE, SLICES BY THE NUMBER OF ITEMS AND STRINGS BY LENGTH
+			if isNumericType(question.Type) && fieldType.Kind() != reflect.Slice {
+				if _, err := parseValue(option, question.Type); err != nil {
+					return nil, fmt.Errorf("INVALID %s: %w", strings.ToUpper(key), err)
+				}
+				if key == "min" {
+					question.Min = option
+				} else {
+					question.Max = option
+				}
+				continue
+			}
+
+			n, err := strconv.Atoi(option)
+			if err != nil {
+				return nil, fmt.Errorf("INVALID %s %q", strings.ToUpper(key), option)
+			}
+			switch {
+			case key == "min" && fieldType.Kind() == reflect.Slice:
+				question.MinItems = n
+			case key == "max" && fieldType.Kind() == reflect.Slice:
+				question.MaxItems = n
+			case key == "min":
+				question.MinLength = n
+			default:
+				question.MaxLength = n
+			}
+		case "validate":
+			question.Validate = strings.Split(option, "|")
+		case "when":
+			question.When = option
+		case "env":
+			question.Env = option
+		case "secret":
+			question.Secret = true
+		case "optional":
+		default:
+			return nil, fmt.Errorf("UNKNOWN TAG OPTION %q", key)
+		}
+	}
+
+	return question, nil
+}
+
+// fieldTypeName returns the question type of a Go type, "" for strings and unknown types
+func fieldTypeName(t reflect.Type) string {
+	switch {
+	case t == durationType:
+		return "duration"
+	case t.Kind() == reflect.Bool:
+		return "boolean"
+	case isIntKind(t.Kind()):
+		return "int"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "float"
+	}
+	return ""
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package survey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clusterConfig struct {
+	Name     string        `survey:"name,prompt='Name of the cluster, lowercase',min=2,max=30"`
+	Nodes    int           `survey:"nodes,prompt=How many nodes?,min=1,max=9"`
+	HA       bool          `survey:"ha,prompt=Highly available?"`
+	Provider string        `survey:"provider,options=vsphere|proxmox"`
+	Addons   []string      `survey:"addons,options=cilium|longhorn|ingress,max=2"`
+	Ports    []int         `survey:"ports,options=80|443,min=1"`
+	Timeout  time.Duration `survey:"timeout,max=1h"`
+	Ratio    float64       `survey:"ratio"`
+	Network  struct {
+		VLAN int `survey:"vlan"`
+	} `survey:"network"`
+	Internal string
+}
+
+func TestQuestionsFromStruct(t *testing.T) {
+	cfg := clusterConfig{Nodes: 3, HA: true, Provider: "proxmox", Addons: []string{"cilium"}, Ports: []int{443}, Timeout: 5 * time.Minute}
+
+	questions, err := QuestionsFromStruct(&cfg)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Question{
+		{Name: "name", Prompt: "Name of the cluster, lowercase", Kind: "ask", MinLength: 2, MaxLength: 30},
+		{Name: "nodes", Prompt: "How many nodes?", Kind: "ask", Type: "int", Default: "3", Min: "1", Max: "9"},
+		{Name: "ha", Prompt: "Highly available?", Kind: "confirm", Type: "boolean", Default: "true"},
+		{Name: "provider", Prompt: "Provider", Kind: "select", Options: NewOptions("vsphere", "proxmox"), Default: "proxmox"},
+		{Name: "addons", Prompt: "Addons", Kind: "list", Options: NewOptions("cilium", "longhorn", "ingress"), Default: "cilium", MaxItems: 2},
+		{Name: "ports", Prompt: "Ports", Kind: "list", Type: "int", Options: NewOptions("80", "443"), Default: "443", MinItems: 1},
+		{Name: "timeout", Prompt: "Timeout", Kind: "ask", Type: "duration", Default: "5m0s", Max: "1h"},
+		{Name: "ratio", Prompt: "Ratio", Kind: "ask", Type: "float"},
+		{Name: "network.vlan", Prompt: "VLAN", Kind: "ask", Type: "int"},
+	}, questions)
+
+	_, err = QuestionsFromStruct(struct {
+		Name string `survey:"name,colour=blue"`
+	}{})
+	assert.Error(t, err)
+
+	_, err = QuestionsFromStruct("not a struct")
+	assert.Error(t, err)
+
+	// MIN AND MAX FOLLOW AN EXPLICIT TYPE WHATEVER THE ORDER OF THE TAG OPTIONS
+	for i := 0; i < 50; i++ {
+		questions, err = QuestionsFromStruct(struct {
+			Replicas string `survey:"replicas,min=2,type=int,max=9"`
+		}{})
+		assert.NoError(t, err)
+		assert.Equal(t, "2", questions[0].Min)
+		assert.Equal(t, "9", questions[0].Max)
+		assert.Zero(t, questions[0].MaxLength)
+	}
+}
+
+func TestQuestionsFromStructRoundTrip(t *testing.T) {
+	cfg := clusterConfig{Name: "dev", Nodes: 3, Provider: "vsphere", Addons: []string{"cilium", "ingress"}, Ports: []int{80, 443}, Timeout: 90 * time.Second, Ratio: 0.5}
+	cfg.Network.VLAN = 42
+
+	questions, err := QuestionsFromStruct(cfg)
+	assert.NoError(t, err)
+
+	answers, err := NewRunner(WithQuestions(questions), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+
+	var decoded clusterConfig
+	assert.NoError(t, Decode(answers, &decoded))
+	assert.Equal(t, cfg, decoded)
+}
@@ -0,0 +1,209 @@
+package survey
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateFunctions are available inside templated defaults, e.g. {{ .username | lower }}-vm
+var TemplateFunctions = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+	"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"default": func(fallback, value interface{}) interface{} {
+		if value == nil || fmt.Sprint(value) == "" {
+			return fallback
+		}
+		return value
+	},
+}
+
+// isTemplate reports whether text contains a go template action
+func isTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// hasTemplate reports whether the default or any default param of q is templated
+func hasTemplate(q *Question) bool {
+	if isTemplate(q.Default) || isTemplate(q.defaultTemplate) {
+		return true
+	}
+	for _, value := range q.DefaultParams {
+		if s, ok := value.(string); ok && isTemplate(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberTemplate keeps the original templated default of q, since q.Default is overwritten by answers
+func rememberTemplate(q *Question) {
+	if q.defaultTemplate == "" && isTemplate(q.Default) {
+		q.defaultTemplate = q.Default
+	}
+}
+
+// renderTemplate renders text against the answers given so far, missing answers render empty
+func renderTemplate(text string, answers map[string]interface{}) (string, error) {
+	if !isTemplate(text) {
+		return text, nil
+	}
+
+	tmpl, err := template.New("default").Funcs(TemplateFunctions).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, templateData(answers)); err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(sb.String(), "<no value>", ""), nil
+}
+
+// templateData returns the data templates are rendered against: the nested answers, so {{ .vm.name }} finds
+// the answer of vm.name, together with the flat dotted names for {{ index . "vm.name" }}
+func templateData(answers map[string]interface{}) map[string]interface{} {
+	data := revealSecrets(nestAnswers(answers))
+	for name, value := range revealSecrets(answers) {
+		if strings.Contains(name, ".") {
+			data[name] = value
+		}
+	}
+	return data
+}
+
+// revealSecrets returns a copy of answers with the plain values of secret answers, templates format
+// values with fmt, which would render the redacted value
+func revealSecrets(answers map[string]interface{}) map[string]interface{} {
+	revealed := make(map[string]interface{}, len(answers))
+	for name, value := range answers {
+		switch v := value.(type) {
+		case Secret:
+			revealed[name] = v.Reveal()
+		case map[string]interface{}:
+			revealed[name] = revealSecrets(v)
+		default:
+			revealed[name] = value
+		}
+	}
+	return revealed
+}
+
+// templateFields returns the answer names referenced by text, e.g. username for {{ .username | lower }}
+// and vm.name for {{ .vm.name }}
+func templateFields(text string) []string {
+	tmpl, err := template.New("default").Funcs(TemplateFunctions).Parse(text)
+	if err != nil || tmpl.Tree == nil {
+		return nil
+	}
+
+	var names []string
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.FieldNode:
+			if name := strings.Join(n.Ident, "."); !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	walk(tmpl.Tree.Root)
+
+	return names
+}
+
+// renderParams returns a copy of params with all string values rendered against answers
+func renderParams(params map[string]interface{}, answers map[string]interface{}) (map[string]interface{}, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	rendered := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		if s, ok := value.(string); ok {
+			r, err := renderTemplate(s, answers)
+			if err != nil {
+				return nil, fmt.Errorf("param %s: %w", key, err)
+			}
+			rendered[key] = r
+			continue
+		}
+		rendered[key] = value
+	}
+	return rendered, nil
+}
+
+// resolveDefault computes the default of q from its default function or its templated default
+func resolveDefault(q *Question, answers map[string]interface{}) (string, error) {
+	if q.Kind == "function" && q.DefaultFunction != "" {
+		fn, ok := DefaultFunctions[q.DefaultFunction]
+		if !ok {
+			return "", &FunctionNotFoundError{Name: q.DefaultFunction, Kind: "DEFAULT"}
+		}
+
+		params, err := renderParams(q.DefaultParams, answers)
+		if err != nil {
+			return "", err
+		}
+		return fn(params), nil
+	}
+
+	rememberTemplate(q)
+	if q.defaultTemplate == "" {
+		return q.Default, nil
+	}
+	return renderTemplate(q.defaultTemplate, answers)
+}
+
+// refreshDefault re-renders a templated default inside a running form, unless the user already changed it
+func refreshDefault(q *Question, answers map[string]interface{}, rebind func()) {
+	if q.Default != q.renderedDefault {
+		return
+	}
+
+	rendered, err := resolveDefault(q, answers)
+	if err != nil || rendered == q.renderedDefault {
+		return
+	}
+
+	q.Default = rendered
+	q.renderedDefault = rendered
+	rebind()
+}
@@ -0,0 +1,80 @@
+package survey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	answers := map[string]interface{}{
+		"username": "Patrick",
+		"vm":       map[string]interface{}{"name": "web01"},
+		"lvm.root": 40,
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain text", "steak", "steak"},
+		{"answer", "{{ .username }}-vm", "Patrick-vm"},
+		{"function", "{{ .username | lower }}-vm", "patrick-vm"},
+		{"nested answer", "{{ .vm.name }}.example.com", "web01.example.com"},
+		{"dotted answer", "{{ .lvm.root }}%", "40%"},
+		{"dotted answer by index", `{{ index . "lvm.root" }}%`, "40%"},
+		{"missing answer", "{{ .missing }}-vm", "-vm"},
+		{"default function", `{{ .missing | default "anonymous" }}`, "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderTemplate(tt.text, answers)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := renderTemplate("{{ .username ", answers)
+	assert.Error(t, err)
+}
+
+func TestResolveDefault(t *testing.T) {
+	RegisterFunction("bucketName", func(params map[string]interface{}) string {
+		return params["prefix"].(string) + "-bucket"
+	})
+
+	answers := map[string]interface{}{"username": "patrick"}
+
+	q := &Question{Name: "hostname", Kind: "ask", Default: "{{ .username }}-vm"}
+	got, err := resolveDefault(q, answers)
+	assert.NoError(t, err)
+	assert.Equal(t, "patrick-vm", got)
+
+	// THE TEMPLATE IS KEPT WHEN DEFAULT IS OVERWRITTEN BY THE RENDERED VALUE
+	q.Default = got
+	got, err = resolveDefault(q, map[string]interface{}{"username": "sina"})
+	assert.NoError(t, err)
+	assert.Equal(t, "sina-vm", got)
+
+	f := &Question{
+		Name:            "bucket",
+		Kind:            "function",
+		DefaultFunction: "bucketName",
+		DefaultParams:   map[string]interface{}{"prefix": "{{ .username }}"},
+	}
+	got, err = resolveDefault(f, answers)
+	assert.NoError(t, err)
+	assert.Equal(t, "patrick-bucket", got)
+}
+
+func TestGetRandomAnswersTemplatedDefault(t *testing.T) {
+	questions := []*Question{
+		{Name: "username", Kind: "ask", Default: "patrick"},
+		{Name: "hostname", Kind: "ask", Default: "{{ .username }}-vm"},
+	}
+
+	answers := GetRandomAnswers(questions)
+	assert.Equal(t, "patrick-vm", answers["hostname"])
+}
@@ -0,0 +1,139 @@
+package survey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseValue converts value to typ like ConvertToType, but reports values which are not valid for typ.
+// List types like "[]int" are read from comma separated values.
+func parseValue(value, typ string) (interface{}, error) {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		return parseList(listItems(value), elem)
+	}
+
+	switch typ {
+	case "int":
+		i, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return 0, fmt.Errorf("%q IS NOT A VALID INT", value)
+		}
+		return i, nil
+	case "float":
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0.0, fmt.Errorf("%q IS NOT A VALID FLOAT", value)
+		}
+		return f, nil
+	case "duration":
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return time.Duration(0), fmt.Errorf("%q IS NOT A VALID DURATION, E.G. 90s OR 1h30m", value)
+		}
+		return d, nil
+	case "boolean":
+		return parseBool(value)
+	}
+	return value, nil
+}
+
+// parseBool reads the spellings of booleans understood by conditions (true/false, yes/no, y/n, on/off, 1/0)
+// case-insensitively, an empty value is false
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "y", "on", "1":
+		return true, nil
+	case "", "false", "no", "n", "off", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q IS NOT A VALID BOOLEAN, E.G. true OR false", value)
+}
+
+// parseList converts every item to elem and returns a typed slice, e.g. []int for "int"
+func parseList(items []string, elem string) (interface{}, error) {
+	switch elem {
+	case "int":
+		return parseItems[int](items, elem)
+	case "float":
+		return parseItems[float64](items, elem)
+	case "duration":
+		return parseItems[time.Duration](items, elem)
+	case "boolean":
+		return parseItems[bool](items, elem)
+	}
+	return parseItems[string](items, elem)
+}
+
+func parseItems[T any](items []string, elem string) ([]T, error) {
+	values := make([]T, len(items))
+	for i, item := range items {
+		value, err := parseValue(item, elem)
+		if err != nil {
+			return []T{}, err
+		}
+		values[i] = value.(T)
+	}
+	return values, nil
+}
+
+// zeroValue returns the answer of typ used for values which cannot be converted
+func zeroValue(typ string) interface{} {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		value, _ := parseList(nil, elem)
+		return value
+	}
+
+	switch typ {
+	case "int":
+		return 0
+	case "float":
+		return 0.0
+	case "duration":
+		return time.Duration(0)
+	case "boolean":
+		return false
+	}
+	return ""
+}
+
+// isNumericType reports whether answers of typ (or its items) are numbers checked against min and max
+func isNumericType(typ string) bool {
+	switch strings.TrimPrefix(typ, "[]") {
+	case "int", "float", "duration":
+		return true
+	}
+	return false
+}
+
+// validateRange checks a converted answer against the min and max bounds of the question,
+// list answers are checked item by item
+func validateRange(question *Question, value interface{}) error {
+	elem := strings.TrimPrefix(question.Type, "[]")
+
+	for _, item := range toSlice(value) {
+		n := numericValue(item)
+
+		if question.Min != "" {
+			if min, err := parseValue(question.Min, elem); err == nil && n < numericValue(min) {
+				return fmt.Errorf("VALUE %v TOO SMALL, MINIMUM IS %s", item, question.Min)
+			}
+		}
+		if question.Max != "" {
+			if max, err := parseValue(question.Max, elem); err == nil && n > numericValue(max) {
+				return fmt.Errorf("VALUE %v TOO LARGE, MAXIMUM IS %s", item, question.Max)
+			}
+		}
+	}
+	return nil
+}
+
+// numericValue returns ints, floats and durations as float64 to compare them
+func numericValue(v interface{}) float64 {
+	if d, ok := v.(time.Duration); ok {
+		return float64(d)
+	}
+	f, _ := toNumber(v)
+	return f
+}
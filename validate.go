@@ -0,0 +1,477 @@
+package survey
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KnownKinds lists the question kinds BuildSurvey can render, "" falls back to a select
+var KnownKinds = []string{"", "select", "ask", "function", "list", "confirm", "password", "text", "computed", "repeat"}
+
+// KnownTypes lists the types ConvertToType understands, "" is treated as string
+var KnownTypes = []string{"", "string", "int", "float", "duration", "boolean", "[]string", "[]int", "[]float", "[]duration", "[]boolean"}
+
+// Diagnostic is a single problem found in a question file
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Question string
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	var location []string
+	if d.File != "" {
+		location = append(location, d.File)
+	}
+	if d.Line > 0 {
+		location = append(location, fmt.Sprintf("%d:%d", d.Line, d.Column))
+	}
+	if d.Question != "" {
+		location = append(location, d.Question)
+	}
+
+	if len(location) == 0 {
+		return d.Message
+	}
+	return strings.Join(location, ":") + ": " + d.Message
+}
+
+// Diagnostics collects all problems found in a question file
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	lines := make([]string, len(d))
+	for i, diagnostic := range d {
+		lines[i] = diagnostic.String()
+	}
+	return fmt.Sprintf("%d PROBLEM(S) FOUND IN QUESTIONS:\n%s", len(d), strings.Join(lines, "\n"))
+}
+
+// ValidateQuestions checks questions for problems LoadQuestionFile accepts silently,
+// all problems are reported at once as Diagnostics
+func ValidateQuestions(questions []*Question) error {
+	var diagnostics Diagnostics
+
+	for _, problem := range checkQuestions(questions) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Question: questions[problem.index].Name,
+			Message:  problem.message,
+		})
+	}
+
+	if len(diagnostics) > 0 {
+		return diagnostics
+	}
+	return nil
+}
+
+// LoadQuestionFileStrict loads the questions like LoadQuestionFile, but also reports unknown fields
+// and all problems of ValidateQuestions with file, line and column
+func LoadQuestionFileStrict(filename, yamlKey string) ([]*Question, error) {
+	survey, err := LoadSurveyFile(filename, yamlKey)
+	if err != nil {
+		return nil, err
+	}
+	questions := survey.Questions
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	list := questionListNode(&root, yamlKey)
+	if list == nil {
+		return nil, &KeyNotFoundError{Key: yamlKey}
+	}
+
+	var diagnostics Diagnostics
+	at := func(node *yaml.Node, question, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     filename,
+			Line:     node.Line,
+			Column:   node.Column,
+			Question: question,
+			Message:  message,
+		})
+	}
+
+	unknownFields := func(mapping *yaml.Node, known map[string]struct{}, question string) {
+		for j := 0; j+1 < len(mapping.Content); j += 2 {
+			key := mapping.Content[j]
+			if _, ok := known[key.Value]; ok {
+				continue
+			}
+
+			message := fmt.Sprintf("unknown field %q", key.Value)
+			if suggestion := closestField(key.Value, known); suggestion != "" {
+				message += fmt.Sprintf(", did you mean %q?", suggestion)
+			}
+			at(key, question, message)
+		}
+	}
+
+	// UNKNOWN FIELDS OF A QUESTION, ITS OPTIONS AND THE QUESTIONS OF ITS REPEAT AND FOREACH BLOCKS
+	known := yamlFields(reflect.TypeOf(Question{}))
+	optionFields := yamlFields(reflect.TypeOf(Option{}))
+	unknownOptionFields := func(options *yaml.Node, question string) {
+		if options == nil || options.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, option := range options.Content {
+			if option.Kind == yaml.MappingNode {
+				unknownFields(option, optionFields, question)
+			}
+		}
+	}
+
+	var unknownQuestionFields func(item *yaml.Node, question string)
+	unknownQuestionFields = func(item *yaml.Node, question string) {
+		unknownFields(item, known, question)
+		unknownOptionFields(fieldNode(item, "options"), question)
+
+		if optionsBy := fieldNode(item, "options_by"); optionsBy != nil && optionsBy.Kind == yaml.MappingNode {
+			unknownFields(optionsBy, yamlFields(reflect.TypeOf(OptionsBy{})), question)
+			if mapped := fieldNode(optionsBy, "map"); mapped != nil && mapped.Kind == yaml.MappingNode {
+				for j := 1; j < len(mapped.Content); j += 2 {
+					unknownOptionFields(mapped.Content[j], question)
+				}
+			}
+		}
+
+		for _, block := range []string{"repeat", "foreach"} {
+			if children := fieldNode(item, block); children != nil && children.Kind == yaml.SequenceNode {
+				for _, child := range children.Content {
+					if child.Kind == yaml.MappingNode {
+						unknownQuestionFields(child, question)
+					}
+				}
+			}
+		}
+	}
+
+	// STRUCTURAL PROBLEMS: ENTRIES WHICH ARE NO MAPPINGS AND UNKNOWN FIELDS
+	for i, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			at(item, "", "question must be a mapping")
+			continue
+		}
+		unknownQuestionFields(item, questionName(questions, i))
+	}
+
+	// SURVEYS IN OBJECT FORM: UNKNOWN SURVEY FIELDS AND INVALID RULES
+	if surveyNode := surveyObjectNode(&root, yamlKey); surveyNode != nil {
+		unknownFields(surveyNode, yamlFields(reflect.TypeOf(Survey{})), "")
+
+		if rules := fieldNode(surveyNode, "rules"); rules != nil && rules.Kind == yaml.SequenceNode {
+			for _, rule := range rules.Content {
+				if rule.Kind == yaml.MappingNode {
+					unknownFields(rule, yamlFields(reflect.TypeOf(Rule{})), "")
+				}
+			}
+			for i, rule := range survey.Rules {
+				_, err := parseExpression(rule.Expr)
+				if err == nil || i >= len(rules.Content) {
+					continue
+				}
+				node := rules.Content[i]
+				if expr := fieldNode(node, "expr"); expr != nil {
+					node = expr
+				}
+				at(node, "", fmt.Sprintf("invalid rule expression: %v", err))
+			}
+		}
+
+		// DESCRIBED PAGES MUST BE USED BY A QUESTION, OTHERWISE THE NAME IS MOST LIKELY MISSPELLED
+		if pages := fieldNode(surveyNode, "pages"); pages != nil && pages.Kind == yaml.SequenceNode {
+			used := make(map[string]bool)
+			for _, question := range questions {
+				used[question.Page] = true
+			}
+			for _, page := range pages.Content {
+				if page.Kind == yaml.MappingNode {
+					unknownFields(page, yamlFields(reflect.TypeOf(Page{})), "")
+				}
+			}
+			for i, page := range survey.Pages {
+				if i >= len(pages.Content) {
+					break
+				}
+				switch {
+				case page.Name == "":
+					at(pages.Content[i], "", "page has no name")
+				case !used[page.Name]:
+					at(pages.Content[i], "", fmt.Sprintf("page %q is not used by any question", page.Name))
+				}
+			}
+		}
+	}
+
+	// SEMANTIC PROBLEMS, REPORTED AT THE OFFENDING FIELD IF IT EXISTS
+	for _, problem := range checkQuestions(questions) {
+		node := list.Content[problem.index]
+		if field := fieldNode(node, problem.field); field != nil {
+			node = field
+		}
+		at(node, questionName(questions, problem.index), problem.message)
+	}
+
+	if len(diagnostics) > 0 {
+		sort.SliceStable(diagnostics, func(i, j int) bool {
+			return diagnostics[i].Line < diagnostics[j].Line
+		})
+		return questions, diagnostics
+	}
+	return questions, nil
+}
+
+// questionProblem is a problem of the question at index, found at field (if not empty)
+type questionProblem struct {
+	index   int
+	field   string
+	message string
+}
+
+// checkQuestions runs all semantic checks on questions
+func checkQuestions(questions []*Question) []questionProblem {
+	var problems []questionProblem
+	seen := make(map[string]int)
+
+	for i, q := range questions {
+		add := func(field, format string, args ...interface{}) {
+			problems = append(problems, questionProblem{index: i, field: field, message: fmt.Sprintf(format, args...)})
+		}
+
+		if q.Name == "" {
+			add("", "question has no name")
+		} else if first, ok := seen[q.Name]; ok {
+			add("name", "duplicate name %q, first used by question %d", q.Name, first+1)
+		} else {
+			seen[q.Name] = i
+		}
+
+		if !contains(KnownKinds, q.Kind) {
+			add("kind", "unknown kind %q, expected one of %s", q.Kind, strings.Join(KnownKinds[1:], ", "))
+		}
+
+		if !contains(KnownTypes, q.Type) {
+			add("type", "unknown type %q, expected one of %s", q.Type, strings.Join(KnownTypes[1:], ", "))
+		}
+
+		if q.MaxLength > 0 && q.MinLength > q.MaxLength {
+			add("minLength", "minLength %d is greater than maxLength %d", q.MinLength, q.MaxLength)
+		}
+
+		for _, field := range []string{"min", "max"} {
+			bound := q.Min
+			if field == "max" {
+				bound = q.Max
+			}
+			if bound == "" {
+				continue
+			}
+			if !isNumericType(q.Type) {
+				add(field, "%s is only supported for int, float and duration types", field)
+			} else if _, err := parseValue(bound, strings.TrimPrefix(q.Type, "[]")); err != nil {
+				add(field, "invalid %s: %v", field, err)
+			}
+		}
+
+		if q.Min != "" && q.Max != "" && isNumericType(q.Type) {
+			elem := strings.TrimPrefix(q.Type, "[]")
+			min, minErr := parseValue(q.Min, elem)
+			max, maxErr := parseValue(q.Max, elem)
+			if minErr == nil && maxErr == nil && numericValue(min) > numericValue(max) {
+				add("min", "min %s is greater than max %s", q.Min, q.Max)
+			}
+		}
+
+		if q.Kind == "computed" && q.Expr == "" && q.Default == "" {
+			add("kind", "computed question needs an expr or a default")
+		}
+		if q.Expr != "" {
+			if q.Kind != "computed" {
+				add("expr", "expr is only used by computed questions")
+			} else if _, err := parseExpression(q.Expr); err != nil {
+				add("expr", "invalid expr: %v", err)
+			}
+		}
+
+		if q.Placeholder != "" && !isInput(q) {
+			add("placeholder", "placeholder is only shown by ask, function, password and text questions")
+		}
+
+		if err := checkValidators(q); err != nil {
+			add("validate", "invalid validate rule: %v", err)
+		}
+
+		if q.Kind == "repeat" && len(q.Repeat) == 0 {
+			add("kind", "repeat question needs child questions in repeat")
+		}
+		for _, problem := range checkQuestions(q.Repeat) {
+			add("repeat", "%s: %s", questionName(q.Repeat, problem.index), problem.message)
+		}
+
+		if len(q.Foreach) > 0 && q.Kind != "list" {
+			add("foreach", "foreach is only supported by list questions")
+		}
+		for _, problem := range checkQuestions(q.Foreach) {
+			add("foreach", "%s: %s", questionName(q.Foreach, problem.index), problem.message)
+		}
+
+		if q.OptionsBy != nil {
+			switch {
+			case q.Kind != "" && q.Kind != "select" && q.Kind != "list":
+				add("options_by", "options_by is only supported by select and list questions")
+			case q.OptionsBy.Field == "":
+				add("options_by", "options_by needs the field it depends on")
+			case len(q.OptionsBy.Map) == 0:
+				add("options_by", "options_by needs a map of options by answer")
+			case len(q.Options) > 0 || q.OptionsFunction != "":
+				add("options_by", "options_by cannot be combined with options or options_function")
+			}
+		}
+
+		if q.MaxItems > 0 && q.MinItems > q.MaxItems {
+			add("minItems", "minItems %d is greater than maxItems %d", q.MinItems, q.MaxItems)
+		}
+
+		if q.When != "" {
+			if _, err := parseExpression(q.When); err != nil {
+				add("when", "invalid when expression: %v", err)
+			}
+		}
+
+		if len(q.Options) > 0 && q.Default != "" && !isTemplate(q.Default) {
+			defaults := []string{q.Default}
+			if q.Kind == "list" {
+				defaults = listItems(q.Default)
+			}
+			values := optionValues(q.Options)
+			for _, d := range defaults {
+				if !contains(values, d) {
+					add("default", "default %q is not one of the options %v", d, values)
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// questionListNode returns the sequence node holding the questions, mirroring LoadQuestionFile
+func questionListNode(root *yaml.Node, yamlKey string) *yaml.Node {
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	doc := root.Content[0]
+	switch doc.Kind {
+	case yaml.SequenceNode:
+		return doc
+	case yaml.MappingNode:
+		value := fieldNode(doc, yamlKey)
+		if object := surveyObjectNode(root, yamlKey); object != nil {
+			value = fieldNode(object, "questions")
+		}
+		if value != nil && value.Kind == yaml.SequenceNode {
+			return value
+		}
+	}
+	return nil
+}
+
+// surveyObjectNode returns the mapping node of a survey in object form, stored under yamlKey
+// or making up the whole file, mirroring LoadSurveyFile
+func surveyObjectNode(root *yaml.Node, yamlKey string) *yaml.Node {
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	doc := root.Content[0]
+	value := fieldNode(doc, yamlKey)
+	if value == nil && fieldNode(doc, "questions") != nil {
+		return doc
+	}
+	if value != nil && value.Kind == yaml.MappingNode {
+		return value
+	}
+	return nil
+}
+
+// fieldNode returns the value node of key in a mapping node
+func fieldNode(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode || key == "" {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func questionName(questions []*Question, index int) string {
+	if index < len(questions) {
+		return questions[index].Name
+	}
+	return ""
+}
+
+// yamlFields returns the yaml field names of the struct type t
+func yamlFields(t reflect.Type) map[string]struct{} {
+	fields := make(map[string]struct{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("yaml")
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			fields[name] = struct{}{}
+		}
+	}
+	return fields
+}
+
+// closestField suggests the known field closest to a misspelled one
+func closestField(field string, known map[string]struct{}) string {
+	best, bestDistance := "", 3
+	for candidate := range known {
+		if d := levenshtein(strings.ToLower(field), strings.ToLower(candidate)); d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,167 @@
+package survey
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var invalidYAML = `
+survey_questions:
+  - prompt: "What is your favorite color?"
+    name: "favorite_color"
+    options: ["Red", "Blue", "Green"]
+    defualt: "Blue"
+    kind: "select"
+  - prompt: "How old are you?"
+    name: "age"
+    kind: "ak"
+    minLength: 30
+    maxLength: 2
+  - prompt: "What is your favorite color again?"
+    name: "favorite_color"
+    options: ["Red", "Blue"]
+    default: "Purple"
+`
+
+func TestLoadQuestionFileStrict(t *testing.T) {
+	filename := createTempYAMLFile(t, invalidYAML)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFileStrict(filename, "survey_questions")
+	assert.Len(t, questions, 3)
+
+	var diagnostics Diagnostics
+	assert.True(t, errors.As(err, &diagnostics))
+
+	expected := []Diagnostic{
+		{File: filename, Line: 6, Column: 5, Question: "favorite_color", Message: `unknown field "defualt", did you mean "default"?`},
+		{File: filename, Line: 10, Column: 11, Question: "age", Message: `unknown kind "ak", expected one of select, ask, function, list, confirm, password, text, computed, repeat`},
+		{File: filename, Line: 11, Column: 16, Question: "age", Message: "minLength 30 is greater than maxLength 2"},
+		{File: filename, Line: 14, Column: 11, Question: "favorite_color", Message: `duplicate name "favorite_color", first used by question 1`},
+		{File: filename, Line: 16, Column: 14, Question: "favorite_color", Message: `default "Purple" is not one of the options [Red Blue]`},
+	}
+	assert.Equal(t, Diagnostics(expected), diagnostics)
+
+	// VALID FILES LOAD WITHOUT DIAGNOSTICS
+	validFile := createTempYAMLFile(t, sampleYAML)
+	defer func() {
+		err := os.Remove(validFile)
+		assert.NoError(t, err)
+	}()
+
+	questions, err = LoadQuestionFileStrict(validFile, "survey_questions")
+	assert.NoError(t, err)
+	assert.Len(t, questions, 2)
+}
+
+func TestLoadQuestionFileStrictRules(t *testing.T) {
+	filename := createTempYAMLFile(t, `
+cluster:
+  questions:
+    - prompt: "Minimum nodes?"
+      name: "min_nodes"
+      kind: "ask"
+  rulez: []
+  rules:
+    - expr: "min_nodes >"
+`)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	questions, err := LoadQuestionFileStrict(filename, "cluster")
+	assert.Len(t, questions, 1)
+	assert.Equal(t, Diagnostics{
+		{File: filename, Line: 7, Column: 3, Message: `unknown field "rulez", did you mean "rules"?`},
+		{File: filename, Line: 9, Column: 13, Message: "invalid rule expression: unexpected end of expression"},
+	}, err)
+}
+
+func TestLoadQuestionFileStrictOptions(t *testing.T) {
+	filename := createTempYAMLFile(t, `
+vm:
+  questions:
+    - prompt: "Size?"
+      name: "size"
+      kind: "select"
+      page: "general"
+      options:
+        - lable: "Small"
+          value: "s"
+    - prompt: "Zone?"
+      name: "zone"
+      kind: "select"
+      options_by:
+        feild: "size"
+        map:
+          s:
+            - value: "fra1"
+              descripton: "Frankfurt"
+  rules:
+    - expr: "size != ''"
+      mesage: "SIZE IS REQUIRED"
+  pages:
+    - name: "general"
+      titel: "General"
+`)
+	defer func() {
+		err := os.Remove(filename)
+		assert.NoError(t, err)
+	}()
+
+	// MISSPELLED FIELDS OF OPTIONS, OPTIONS_BY, RULES AND PAGES ARE REPORTED LIKE THOSE OF QUESTIONS
+	_, err := LoadQuestionFileStrict(filename, "vm")
+	assert.Equal(t, Diagnostics{
+		{File: filename, Line: 9, Column: 11, Question: "size", Message: `unknown field "lable", did you mean "label"?`},
+		{File: filename, Line: 15, Column: 9, Question: "zone", Message: `unknown field "feild", did you mean "field"?`},
+		{File: filename, Line: 15, Column: 9, Question: "zone", Message: "options_by needs the field it depends on"},
+		{File: filename, Line: 19, Column: 15, Question: "zone", Message: `unknown field "descripton", did you mean "description"?`},
+		{File: filename, Line: 22, Column: 7, Message: `unknown field "mesage", did you mean "message"?`},
+		{File: filename, Line: 25, Column: 7, Message: `unknown field "titel", did you mean "title"?`},
+	}, err)
+}
+
+func TestValidateQuestions(t *testing.T) {
+	assert.NoError(t, ValidateQuestions([]*Question{
+		{Name: "username", Kind: "ask", MinLength: 2, MaxLength: 30},
+		{Name: "hostname", Kind: "ask", Default: "{{ .username }}-vm", When: "username != ''"},
+		{Name: "timeout", Kind: "ask", Type: "duration", Min: "1s", Max: "1h"},
+	}))
+
+	err := ValidateQuestions([]*Question{
+		{Name: "age", Kind: "ask", Type: "int", Min: "120", Max: "18"},
+		{Name: "timeout", Kind: "ask", Type: "duration", Min: "1"},
+		{Name: "username", Kind: "ask", Max: "30"},
+		{Name: "hostname", Kind: "ask", Validate: []string{"hostname", "regex:[a-"}},
+		{Name: "fqdn", Kind: "computed"},
+		{Name: "domain", Kind: "ask", Expr: "hostname"},
+		{Name: "zone", Kind: "select", Foreach: []*Question{{Name: "vlan", Kind: "ask"}}},
+		{Name: "datacenter", Kind: "select", OptionsBy: &OptionsBy{Map: map[string][]Option{"eu": NewOptions("fra1")}}},
+	})
+	assert.Equal(t, "8 PROBLEM(S) FOUND IN QUESTIONS:\n"+
+		"age: min 120 is greater than max 18\n"+
+		"timeout: invalid min: \"1\" IS NOT A VALID DURATION, E.G. 90s OR 1h30m\n"+
+		"username: max is only supported for int, float and duration types\n"+
+		"hostname: invalid validate rule: INVALID REGEX \"[a-\": error parsing regexp: missing closing ]: `[a-`\n"+
+		"fqdn: computed question needs an expr or a default\n"+
+		"domain: expr is only used by computed questions\n"+
+		"zone: foreach is only supported by list questions\n"+
+		"datacenter: options_by needs the field it depends on", err.Error())
+
+	err = ValidateQuestions([]*Question{
+		{Kind: "ask"},
+		{Name: "size", Type: "decimal", When: "a =="},
+	})
+	var diagnostics Diagnostics
+	assert.True(t, errors.As(err, &diagnostics))
+	assert.Len(t, diagnostics, 3)
+	assert.Equal(t, "question has no name", diagnostics[0].String())
+	assert.Contains(t, err.Error(), "3 PROBLEM(S) FOUND")
+}
@@ -0,0 +1,157 @@
+package survey
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Validators holds the registered validators by name, see RegisterValidator
+var Validators = map[string]func(input, arg string) error{
+	"hostname": validateHostname,
+	"k8s-name": validateK8sName,
+	"semver":   validateSemver,
+	"email":    validateEmail,
+	"ip":       validateIP,
+	"cidr":     validateCIDR,
+	"url":      validateURL,
+	"regex":    validateRegex,
+}
+
+// RegisterValidator adds a validator which can be referenced in the validate list of a question.
+// A rule like "regex:^[a-z]+$" calls the validator "regex" with the argument "^[a-z]+$".
+func RegisterValidator(name string, fn func(input, arg string) error) {
+	Validators[name] = fn
+}
+
+// runValidators checks input against all validate rules of the question,
+// the items of list types are checked one by one. Empty input passes, requiring an answer is left to
+// min_length. Validators quote the input in their errors, so the errors of secret questions are replaced
+// by a message without the input.
+func runValidators(question *Question, input string) error {
+	if len(question.Validate) == 0 {
+		return nil
+	}
+
+	items := []string{input}
+	if strings.HasPrefix(question.Type, "[]") {
+		items = listItems(input)
+	}
+
+	for _, rule := range question.Validate {
+		name, arg, _ := strings.Cut(rule, ":")
+		fn, ok := Validators[name]
+		if !ok {
+			return &FunctionNotFoundError{Name: name, Kind: "VALIDATOR"}
+		}
+		for _, item := range items {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
+			if err := fn(item, arg); err != nil {
+				if isSecret(question) {
+					return fmt.Errorf("INPUT IS NOT VALID, CHECK %s", name)
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkValidators reports unknown validators and invalid arguments before the validators are run
+func checkValidators(question *Question) error {
+	for _, rule := range question.Validate {
+		name, arg, _ := strings.Cut(rule, ":")
+		if _, ok := Validators[name]; !ok {
+			return &FunctionNotFoundError{Name: name, Kind: "VALIDATOR"}
+		}
+		if name == "regex" {
+			if _, err := regexp.Compile(arg); err != nil {
+				return fmt.Errorf("INVALID REGEX %q: %w", arg, err)
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	k8sName       = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	semver        = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+)
+
+// validateHostname accepts RFC 1123 host names like web01 or web01.example.com
+func validateHostname(input, _ string) error {
+	if len(input) == 0 || len(input) > 253 {
+		return fmt.Errorf("%q IS NOT A VALID HOSTNAME", input)
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(input, "."), ".") {
+		if !hostnameLabel.MatchString(label) {
+			return fmt.Errorf("%q IS NOT A VALID HOSTNAME", input)
+		}
+	}
+	return nil
+}
+
+// validateK8sName accepts kubernetes resource names (RFC 1123 labels, lower case, at most 63 characters)
+func validateK8sName(input, _ string) error {
+	if len(input) > 63 || !k8sName.MatchString(input) {
+		return fmt.Errorf("%q IS NOT A VALID KUBERNETES NAME, USE UP TO 63 LOWER CASE LETTERS, DIGITS AND -", input)
+	}
+	return nil
+}
+
+// validateSemver accepts semantic versions like 1.2.3, v1.2.3 or 1.2.3-rc.1+build.5
+func validateSemver(input, _ string) error {
+	if !semver.MatchString(input) {
+		return fmt.Errorf("%q IS NOT A VALID SEMANTIC VERSION, E.G. 1.2.3", input)
+	}
+	return nil
+}
+
+func validateEmail(input, _ string) error {
+	address, err := mail.ParseAddress(input)
+	if err != nil || address.Address != input {
+		return fmt.Errorf("%q IS NOT A VALID EMAIL ADDRESS", input)
+	}
+	return nil
+}
+
+func validateIP(input, _ string) error {
+	if net.ParseIP(input) == nil {
+		return fmt.Errorf("%q IS NOT A VALID IP ADDRESS", input)
+	}
+	return nil
+}
+
+func validateCIDR(input, _ string) error {
+	if _, _, err := net.ParseCIDR(input); err != nil {
+		return fmt.Errorf("%q IS NOT A VALID CIDR, E.G. 10.0.0.0/24", input)
+	}
+	return nil
+}
+
+// validateURL accepts absolute URLs with scheme and host
+func validateURL(input, _ string) error {
+	u, err := url.Parse(input)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%q IS NOT A VALID URL", input)
+	}
+	return nil
+}
+
+// validateRegex accepts inputs matching the regular expression given as argument
+func validateRegex(input, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("INVALID REGEX %q: %w", pattern, err)
+	}
+	if !re.MatchString(input) {
+		return fmt.Errorf("%q DOES NOT MATCH %s", input, pattern)
+	}
+	return nil
+}
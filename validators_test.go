@@ -0,0 +1,93 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		rule    string
+		valid   []string
+		invalid []string
+	}{
+		{"hostname", []string{"web01", "web01.example.com"}, []string{"-web", "web_01", "web..com"}},
+		{"k8s-name", []string{"my-app", "app1"}, []string{"My-App", "app-", "a.b"}},
+		{"semver", []string{"1.2.3", "v0.1.0", "1.0.0-rc.1+build.5"}, []string{"1.2", "01.2.3", "latest"}},
+		{"email", []string{"patrick@example.com"}, []string{"patrick", "Patrick <patrick@example.com>"}},
+		{"ip", []string{"10.0.0.1", "::1"}, []string{"10.0.0.256"}},
+		{"cidr", []string{"10.0.0.0/24"}, []string{"10.0.0.0", "10.0.0.0/33"}},
+		{"url", []string{"https://example.com/path"}, []string{"example.com", "/path"}},
+		{"regex:^[a-z]+$", []string{"abc"}, []string{"abc1", "ABC"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			q := &Question{Name: "value", Validate: []string{tt.rule}}
+			for _, input := range tt.valid {
+				assert.NoError(t, validateInput(q, input), input)
+			}
+			for _, input := range tt.invalid {
+				assert.Error(t, validateInput(q, input), input)
+			}
+		})
+	}
+}
+
+func TestValidatorsEmpty(t *testing.T) {
+	// VALIDATORS ONLY CHECK GIVEN ANSWERS, AN ANSWER IS REQUIRED BY MIN_LENGTH
+	answers, err := NewRunner(WithQuestions([]*Question{
+		{Name: "email", Kind: "ask", Validate: []string{"email"}},
+		{Name: "hosts", Kind: "ask", Type: "[]string", Default: "web01,,db01", Validate: []string{"hostname"}},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "", answers["email"])
+
+	_, err = NewRunner(WithQuestions([]*Question{
+		{Name: "email", Kind: "ask", Validate: []string{"email"}, MinLength: 1},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.ErrorContains(t, err, "INPUT TOO SHORT")
+}
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("prefix", func(input, arg string) error {
+		if len(input) < len(arg) || input[:len(arg)] != arg {
+			return fmt.Errorf("%q DOES NOT START WITH %s", input, arg)
+		}
+		return nil
+	})
+
+	q := &Question{Name: "namespaces", Type: "[]string", Validate: []string{"prefix:team-", "k8s-name"}}
+	assert.NoError(t, validateInput(q, "team-a,team-b"))
+	assert.Error(t, validateInput(q, "team-a,other"))
+
+	_, err := NewRunner(WithQuestions([]*Question{
+		{Name: "hostname", Kind: "ask", Default: "web_01", Validate: []string{"hostname"}},
+	}), WithInteractive(false)).Run(context.Background())
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+
+	_, _, err = BuildSurvey([]*Question{{Name: "hostname", Kind: "ask", Validate: []string{"notRegistered"}}})
+	var fnErr *FunctionNotFoundError
+	assert.True(t, errors.As(err, &fnErr))
+	assert.Equal(t, "VALIDATOR", fnErr.Kind)
+}
+
+func TestValidatorsSecret(t *testing.T) {
+	q := &Question{Name: "pw", Kind: "password", Validate: []string{"regex:^[0-9]+$"}}
+	assert.NoError(t, validateInput(q, "1234"))
+
+	// ERRORS OF SECRET QUESTIONS DO NOT SHOW THE INPUT
+	err := validateInput(q, "hunter2")
+	assert.EqualError(t, err, "INPUT IS NOT VALID, CHECK regex")
+
+	_, err = NewRunner(WithQuestions([]*Question{
+		{Name: "pw", Kind: "password", Default: "hunter2", Validate: []string{"regex:^[0-9]+$"}},
+	}), WithInteractive(false)).Run(context.Background())
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+}